@@ -0,0 +1,232 @@
+package balatro
+
+import (
+	"fmt"
+)
+
+type BlindKind int
+
+const (
+	SmallBlind BlindKind = iota
+	BigBlind
+	BossBlind
+)
+
+// WinningAnte is the ante whose boss blind ends the run
+const WinningAnte = 8
+
+func (b BlindKind) String() string {
+	switch b {
+	case SmallBlind:
+		return "Small Blind"
+	case BigBlind:
+		return "Big Blind"
+	case BossBlind:
+		return "Boss Blind"
+	default:
+		return "Unknown Blind"
+	}
+}
+
+// anteBaseScores are the small blind targets for antes 1 through 8
+var anteBaseScores = []int{300, 800, 2000, 5000, 11000, 20000, 35000, 50000}
+
+// GetTarget returns the score needed to clear the blind in the given ante at
+// the given stake
+func (b BlindKind) GetTarget(ante int, stake Stake) int {
+	if ante < 1 {
+		ante = 1
+	}
+	scores := stake.anteScores()
+	base := scores[len(scores)-1]
+	if ante <= len(scores) {
+		base = scores[ante-1]
+	}
+
+	switch b {
+	case BigBlind:
+		return base * 3 / 2
+	case BossBlind:
+		return base * 2
+	default:
+		return base
+	}
+}
+
+// GetReward returns the dollars paid for clearing the blind
+func (b BlindKind) GetReward(stake Stake) int {
+	switch b {
+	case SmallBlind:
+		if stake >= RedStake {
+			return 0
+		}
+		return 3
+	case BigBlind:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// Payout breaks down the money earned when a blind is cleared
+type Payout struct {
+	Blind    int
+	Hands    int
+	Interest int
+	Jokers   int
+	Held     int
+}
+
+func (p Payout) Total() int {
+	return p.Blind + p.Hands + p.Interest + p.Jokers + p.Held
+}
+
+// interest pays $1 for every $5 held, up to $5
+func interest(money int) int {
+	if money <= 0 {
+		return 0
+	}
+	earned := money / 5
+	if earned > 5 {
+		return 5
+	}
+	return earned
+}
+
+// BossKind is the rule a boss blind changes. NoBoss applies outside of boss blinds.
+type BossKind int
+
+const (
+	NoBoss BossKind = iota
+	TheClub
+	TheGoad
+	TheHead
+	TheWindow
+	TheHouse
+	TheMark
+	TheEye
+	TheMouth
+	TheFlint
+	TheHook
+)
+
+// BossKinds lists the bosses an ante can draw
+var BossKinds = []BossKind{TheClub, TheGoad, TheHead, TheWindow, TheHouse, TheMark, TheEye, TheMouth, TheFlint, TheHook}
+
+func (b BossKind) String() string {
+	switch b {
+	case TheClub:
+		return "The Club"
+	case TheGoad:
+		return "The Goad"
+	case TheHead:
+		return "The Head"
+	case TheWindow:
+		return "The Window"
+	case TheHouse:
+		return "The House"
+	case TheMark:
+		return "The Mark"
+	case TheEye:
+		return "The Eye"
+	case TheMouth:
+		return "The Mouth"
+	case TheFlint:
+		return "The Flint"
+	case TheHook:
+		return "The Hook"
+	default:
+		return "No Boss"
+	}
+}
+
+// Description explains the boss's rule for the CLI
+func (b BossKind) Description() string {
+	switch b {
+	case TheClub, TheGoad, TheHead, TheWindow:
+		suit, _ := b.debuffedSuit()
+		return fmt.Sprintf("All %s cards are debuffed", suit)
+	case TheHouse:
+		return "The first hand is dealt face down"
+	case TheMark:
+		return "All face cards are dealt face down"
+	case TheEye:
+		return "No repeated hand types this round"
+	case TheMouth:
+		return "Play only 1 hand type this round"
+	case TheFlint:
+		return "Base chips and multiplier are halved"
+	case TheHook:
+		return "Discards 2 random cards held in hand after every hand played"
+	default:
+		return ""
+	}
+}
+
+func (b BossKind) debuffedSuit() (Suit, bool) {
+	switch b {
+	case TheClub:
+		return Clubs, true
+	case TheGoad:
+		return Spades, true
+	case TheHead:
+		return Hearts, true
+	case TheWindow:
+		return Diamonds, true
+	default:
+		return Hearts, false
+	}
+}
+
+// Debuffs reports whether the card scores nothing under this boss. Debuffed
+// cards still count towards the hand type.
+func (b BossKind) Debuffs(card Card) bool {
+	suit, ok := b.debuffedSuit()
+	return ok && card.HasRank() && card.Suit == suit
+}
+
+// DealsFaceDown reports whether a card drawn now is hidden from the player.
+// firstHand is true while the opening hand of the blind is being dealt.
+func (b BossKind) DealsFaceDown(card Card, firstHand bool) bool {
+	switch b {
+	case TheHouse:
+		return firstHand
+	case TheMark:
+		return card.IsFace()
+	default:
+		return false
+	}
+}
+
+// Allows reports whether the hand type may be played, given the hand types
+// already played this blind
+func (b BossKind) Allows(handType HandType, played []HandType) bool {
+	switch b {
+	case TheEye:
+		for _, previous := range played {
+			if previous == handType {
+				return false
+			}
+		}
+	case TheMouth:
+		return len(played) == 0 || played[0] == handType
+	}
+	return true
+}
+
+// adjustBase halves the hand's starting chips and multiplier under The Flint,
+// rounding up
+func (b BossKind) adjustBase(chips int, mult int) (int, int) {
+	if b != TheFlint {
+		return chips, mult
+	}
+	return (chips + 1) / 2, (mult + 1) / 2
+}
+
+// HeldDiscards returns how many held cards are discarded after each hand
+func (b BossKind) HeldDiscards() int {
+	if b == TheHook {
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,136 @@
+package balatro
+
+import (
+	"testing"
+)
+
+func newBossGame(seed int64, boss BossKind) *Game {
+	g := newTestGame(seed)
+	g.Blind = BossBlind
+	g.Boss = boss
+	g.StartBlind()
+	return g
+}
+
+func TestBlindTargets(t *testing.T) {
+	if SmallBlind.GetTarget(1, WhiteStake) != 300 || BigBlind.GetTarget(1, WhiteStake) != 450 || BossBlind.GetTarget(1, WhiteStake) != 600 {
+		t.Errorf("Unexpected ante 1 targets: %d, %d, %d", SmallBlind.GetTarget(1, WhiteStake), BigBlind.GetTarget(1, WhiteStake), BossBlind.GetTarget(1, WhiteStake))
+	}
+	if BossBlind.GetTarget(20, WhiteStake) != 100000 {
+		t.Errorf("Expected antes past 8 to keep the ante 8 target, got %d", BossBlind.GetTarget(20, WhiteStake))
+	}
+}
+
+func TestStakesRaiseTargets(t *testing.T) {
+	for ante := 2; ante <= WinningAnte; ante++ {
+		white := SmallBlind.GetTarget(ante, WhiteStake)
+		green := SmallBlind.GetTarget(ante, GreenStake)
+		purple := SmallBlind.GetTarget(ante, PurpleStake)
+		if !(white < green && green < purple) {
+			t.Errorf("Ante %d: expected targets to rise with the stake, got %d, %d, %d", ante, white, green, purple)
+		}
+	}
+	if SmallBlind.GetReward(WhiteStake) != 3 || SmallBlind.GetReward(RedStake) != 0 {
+		t.Error("Expected the Red stake to remove the small blind reward")
+	}
+}
+
+func TestSuitDebuff(t *testing.T) {
+	hand := Hand{{Suit: Clubs, Rank: Ace}, {Suit: Hearts, Rank: Ace}}
+	eval := EvaluateHandWithContext(hand, ScoringContext{Boss: TheClub, Jokers: []Joker{{Kind: GluttonousJoker}}})
+	if eval.Type != Pair {
+		t.Errorf("Debuffed cards should still count towards the hand, got %s", eval.Type)
+	}
+	if eval.CardValue != 11 || eval.Mult != 2 {
+		t.Errorf("Expected only the heart to score, got %d x%.1f", eval.CardValue, eval.Mult)
+	}
+
+	eval = EvaluateHandWithContext(hand, ScoringContext{Boss: NoBoss})
+	if eval.CardValue != 22 {
+		t.Errorf("Expected both cards to score without a boss, got %d", eval.CardValue)
+	}
+}
+
+func TestTheFlint(t *testing.T) {
+	hand := Hand{{Suit: Clubs, Rank: Ace}, {Suit: Hearts, Rank: Ace}, {Suit: Spades, Rank: Ace}}
+	levels := HandLevels{ThreeOfAKind: 2}
+	eval := EvaluateHandWithContext(hand, ScoringContext{Boss: TheFlint, Levels: levels})
+	// Level 2 Three of a Kind: 20 chips and x6, halved to 10 and x3
+	if eval.Multiplier != 3 || eval.CardValue != 10+33 {
+		t.Errorf("Expected 43 chips x3, got %d x%d", eval.CardValue, eval.Multiplier)
+	}
+}
+
+func TestOneHandTypeBosses(t *testing.T) {
+	pair := Hand{{Suit: Clubs, Rank: Ace}, {Suit: Hearts, Rank: Ace}}
+	high := Hand{{Suit: Clubs, Rank: Ace}}
+
+	eval := EvaluateHandWithContext(pair, ScoringContext{Boss: TheEye, PlayedHands: []HandType{Pair}})
+	if !eval.NotAllowed || eval.TotalScore != 0 {
+		t.Errorf("The Eye should forbid a second Pair, got %+v", eval)
+	}
+	eval = EvaluateHandWithContext(high, ScoringContext{Boss: TheEye, PlayedHands: []HandType{Pair}})
+	if eval.NotAllowed {
+		t.Error("The Eye should allow a new hand type")
+	}
+	eval = EvaluateHandWithContext(high, ScoringContext{Boss: TheMouth, PlayedHands: []HandType{Pair}})
+	if !eval.NotAllowed {
+		t.Error("The Mouth should only allow the first hand type")
+	}
+}
+
+func TestFaceDownBosses(t *testing.T) {
+	g := newBossGame(21, TheHouse)
+	for _, card := range g.PlayerHand {
+		if !card.FaceDown {
+			t.Fatalf("The House should deal the first hand face down, got %s", Hand(g.PlayerHand))
+		}
+	}
+	if _, err := g.Discard([]int{0}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if g.PlayerHand[len(g.PlayerHand)-1].FaceDown {
+		t.Error("The House should only hide the first hand")
+	}
+	played, _, err := g.PlayHand([]int{0})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if played[0].FaceDown {
+		t.Error("Played cards should be revealed")
+	}
+
+	g = newBossGame(22, TheMark)
+	for _, card := range g.PlayerHand {
+		if card.FaceDown != card.IsFace() {
+			t.Errorf("The Mark should hide exactly the face cards, got %+v", card)
+		}
+	}
+}
+
+func TestTheHook(t *testing.T) {
+	g := newBossGame(23, TheHook)
+	before := len(g.Deck.Cards)
+	if _, _, err := g.PlayHand([]int{0}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// One card played and two discarded by The Hook are replaced from the deck
+	if drawn := before - len(g.Deck.Cards); drawn != 3 {
+		t.Errorf("Expected 3 replacement cards, got %d", drawn)
+	}
+	if len(g.PlayerHand) != HandSize {
+		t.Errorf("Expected a full hand, got %d cards", len(g.PlayerHand))
+	}
+}
+
+func TestBossOnlyAppliesToBossBlind(t *testing.T) {
+	g := newTestGame(24)
+	g.Boss = TheMark
+	if g.ActiveBoss() != NoBoss {
+		t.Errorf("Expected no active boss in the small blind, got %s", g.ActiveBoss())
+	}
+	g.Blind = BossBlind
+	if g.ActiveBoss() != TheMark {
+		t.Errorf("Expected The Mark in the boss blind, got %s", g.ActiveBoss())
+	}
+}
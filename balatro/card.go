@@ -5,7 +5,6 @@ import (
 	"math/rand"
 	"sort"
 	"strings"
-	"time"
 )
 
 type Suit int
@@ -69,7 +68,7 @@ func (r Rank) String() string {
 
 // GetValue returns the scoring value for a card (face cards = 10, aces = 11)
 func (r Rank) GetValue() int {
-	if r >= Jack && r <= King {
+	if r.IsFace() {
 		return 10
 	}
 	if r == Ace {
@@ -78,19 +77,167 @@ func (r Rank) GetValue() int {
 	return int(r)
 }
 
+// IsFace reports whether the rank is a face card (Jack, Queen or King)
+func (r Rank) IsFace() bool {
+	return r >= Jack && r <= King
+}
+
+// Enhancement changes how a card scores while it stays in the deck
+type Enhancement int
+
+const (
+	NoEnhancement Enhancement = iota
+	BonusCard
+	MultCard
+	WildCard
+	GlassCard
+	SteelCard
+	StoneCard
+	GoldCard
+	LuckyCard
+)
+
+func (e Enhancement) String() string {
+	switch e {
+	case BonusCard:
+		return "Bonus"
+	case MultCard:
+		return "Mult"
+	case WildCard:
+		return "Wild"
+	case GlassCard:
+		return "Glass"
+	case SteelCard:
+		return "Steel"
+	case StoneCard:
+		return "Stone"
+	case GoldCard:
+		return "Gold"
+	case LuckyCard:
+		return "Lucky"
+	default:
+		return ""
+	}
+}
+
+// Edition is a cosmetic finish that adds to the score when the card is played
+type Edition int
+
+const (
+	NoEdition Edition = iota
+	Foil
+	Holographic
+	Polychrome
+)
+
+func (e Edition) String() string {
+	switch e {
+	case Foil:
+		return "Foil"
+	case Holographic:
+		return "Holographic"
+	case Polychrome:
+		return "Polychrome"
+	default:
+		return ""
+	}
+}
+
+// Seal triggers an extra effect when the card is played, held or discarded
+type Seal int
+
+const (
+	NoSeal Seal = iota
+	GoldSeal
+	RedSeal
+	BlueSeal
+	PurpleSeal
+)
+
+func (s Seal) String() string {
+	switch s {
+	case GoldSeal:
+		return "Gold Seal"
+	case RedSeal:
+		return "Red Seal"
+	case BlueSeal:
+		return "Blue Seal"
+	case PurpleSeal:
+		return "Purple Seal"
+	default:
+		return ""
+	}
+}
+
 type Card struct {
-	Suit Suit
-	Rank Rank
+	Suit        Suit
+	Rank        Rank
+	Enhancement Enhancement
+	Edition     Edition
+	Seal        Seal
+	// ID tells apart cards in a run's full deck, which may hold duplicates
+	ID int
+	// FaceDown hides the card from the player until it is played
+	FaceDown bool
 }
 
 func (c Card) String() string {
-	return fmt.Sprintf("%s%s", c.Rank, c.Suit)
+	if c.FaceDown {
+		return "??"
+	}
+	base := fmt.Sprintf("%s%s", c.Rank, c.Suit)
+	if c.Enhancement == StoneCard {
+		base = "Stone"
+	}
+
+	var modifiers []string
+	if c.Enhancement != NoEnhancement && c.Enhancement != StoneCard {
+		modifiers = append(modifiers, c.Enhancement.String())
+	}
+	if c.Edition != NoEdition {
+		modifiers = append(modifiers, c.Edition.String())
+	}
+	if c.Seal != NoSeal {
+		modifiers = append(modifiers, c.Seal.String())
+	}
+	if len(modifiers) == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s[%s]", base, strings.Join(modifiers, ","))
 }
 
+// GetValue returns the chips the card adds when scored. Stone cards have no
+// rank, so they are always worth a flat 50.
 func (c Card) GetValue() int {
+	if c.Enhancement == StoneCard {
+		return 50
+	}
 	return c.Rank.GetValue()
 }
 
+// HasRank reports whether the card counts towards pairs and straights
+func (c Card) HasRank() bool {
+	return c.Enhancement != StoneCard
+}
+
+// IsSuit reports whether the card counts as the given suit. Wild cards count
+// as every suit, Stone cards as none.
+func (c Card) IsSuit(suit Suit) bool {
+	switch c.Enhancement {
+	case WildCard:
+		return true
+	case StoneCard:
+		return false
+	default:
+		return c.Suit == suit
+	}
+}
+
+// IsFace reports whether the card counts as a face card
+func (c Card) IsFace() bool {
+	return c.HasRank() && c.Rank.IsFace()
+}
+
 type Deck struct {
 	Cards []Card
 }
@@ -100,16 +247,61 @@ func NewDeck() *Deck {
 	
 	for suit := Hearts; suit <= Spades; suit++ {
 		for rank := Two; rank <= Ace; rank++ {
-			deck.Cards = append(deck.Cards, Card{Suit: suit, Rank: rank})
+			deck.Add(Card{Suit: suit, Rank: rank})
 		}
 	}
 	
 	return deck
 }
 
-func (d *Deck) Shuffle() {
-	rand.Seed(time.Now().UnixNano())
-	rand.Shuffle(len(d.Cards), func(i, j int) {
+// Clone returns a copy of the deck that can be drawn from without changing the original
+func (d *Deck) Clone() *Deck {
+	cards := make([]Card, len(d.Cards))
+	copy(cards, d.Cards)
+	return &Deck{Cards: cards}
+}
+
+// Add puts a copy of the card in the deck under a new ID and returns it.
+// Cards are always stored face up.
+func (d *Deck) Add(card Card) Card {
+	card.FaceDown = false
+	card.ID = 1
+	for _, existing := range d.Cards {
+		if existing.ID >= card.ID {
+			card.ID = existing.ID + 1
+		}
+	}
+	d.Cards = append(d.Cards, card)
+	return card
+}
+
+// Remove takes the card with the given ID out of the deck
+func (d *Deck) Remove(id int) bool {
+	for idx, card := range d.Cards {
+		if card.ID == id {
+			d.Cards = append(d.Cards[:idx], d.Cards[idx+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Replace overwrites the card that has the same ID
+func (d *Deck) Replace(card Card) bool {
+	card.FaceDown = false
+	for idx, existing := range d.Cards {
+		if existing.ID == card.ID {
+			d.Cards[idx] = card
+			return true
+		}
+	}
+	return false
+}
+
+// Shuffle shuffles the deck using the given random source, so a seeded run
+// always deals the same cards
+func (d *Deck) Shuffle(rng *rand.Rand) {
+	rng.Shuffle(len(d.Cards), func(i, j int) {
 		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
 	})
 }
@@ -0,0 +1,226 @@
+package balatro
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CommandKind is what a line typed at the card selection prompt asks for
+type CommandKind int
+
+const (
+	// SelectCommand adds cards to the selection, e.g. "1 3" or "AH KS"
+	SelectCommand CommandKind = iota
+	PlayCommand
+	DiscardCommand
+	SortCommand
+	UseCommand
+	SuggestCommand
+	DeckCommand
+	DoneCommand
+	HelpCommand
+)
+
+// Command is a parsed line from the card selection prompt
+type Command struct {
+	Kind CommandKind
+	// Cards are the 0-based hand positions named by the command. Play and
+	// discard without cards act on the current selection.
+	Cards []int
+	// BySuit sorts by suit instead of rank
+	BySuit bool
+	// Consumable is the 0-based consumable slot to use
+	Consumable int
+}
+
+const commandHelp = `Commands:
+  1 3 5, 1-3, AH KS    add cards to the selection by position or name
+  play [cards]         play the cards, or the current selection
+  discard [cards]      discard the cards, or the current selection
+  sort rank|suit       sort the hand
+  use <n> [cards]      use consumable n on the cards, or the current selection
+  suggest              list the best hands
+  deck                 show the draw pile
+  done                 finish selecting, then choose to play or discard
+  help                 show this help
+Card names are a rank (A K Q J T or 2-10) followed by a suit (H D C S).
+Shortcuts: p play, d discard, u use, a suggest (advice), h help.`
+
+// ParseCommand parses a line typed at the card selection prompt. Card
+// positions and names are checked against the hand.
+func ParseCommand(input string, hand Hand) (Command, error) {
+	fields := strings.Fields(strings.ToLower(input))
+	if len(fields) == 0 {
+		return Command{}, fmt.Errorf("type a command, or 'help' to list them")
+	}
+
+	name, args := fields[0], fields[1:]
+	switch name {
+	case "play", "p":
+		cards, err := parseCards(args, hand)
+		return Command{Kind: PlayCommand, Cards: cards}, err
+	case "discard", "d":
+		cards, err := parseCards(args, hand)
+		return Command{Kind: DiscardCommand, Cards: cards}, err
+	case "sort":
+		if len(args) != 1 || (args[0] != "rank" && args[0] != "suit") {
+			return Command{}, fmt.Errorf("usage: sort rank|suit")
+		}
+		return Command{Kind: SortCommand, BySuit: args[0] == "suit"}, nil
+	case "use", "u":
+		if len(args) == 0 {
+			return Command{}, fmt.Errorf("usage: use <n> [cards]")
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return Command{}, fmt.Errorf("%q is not a consumable number", args[0])
+		}
+		cards, err := parseCards(args[1:], hand)
+		return Command{Kind: UseCommand, Consumable: n - 1, Cards: cards}, err
+	case "suggest", "a":
+		return noArgs(SuggestCommand, name, args)
+	case "deck":
+		return noArgs(DeckCommand, name, args)
+	case "done":
+		return noArgs(DoneCommand, name, args)
+	case "help", "h", "?":
+		return noArgs(HelpCommand, name, args)
+	}
+
+	cards, err := parseCards(fields, hand)
+	if err != nil {
+		if _, _, ok := parseCardName(name); !ok && !isPosition(name) {
+			return Command{}, fmt.Errorf("unknown command %q, type 'help' to list them", name)
+		}
+		return Command{}, err
+	}
+	return Command{Kind: SelectCommand, Cards: cards}, nil
+}
+
+func noArgs(kind CommandKind, name string, args []string) (Command, error) {
+	if len(args) > 0 {
+		return Command{}, fmt.Errorf("%s doesn't take any arguments", name)
+	}
+	return Command{Kind: kind}, nil
+}
+
+// isPosition reports whether the word looks like a card position or range
+func isPosition(word string) bool {
+	return strings.Trim(word, "0123456789-") == "" && strings.ContainsAny(word, "0123456789")
+}
+
+// parseCards resolves card positions ("3"), ranges ("1-3") and names ("AH")
+// to 0-based hand positions. A name picks the first matching card that isn't
+// already listed, so "7H 7H" picks two different Sevens of Hearts.
+func parseCards(words []string, hand Hand) ([]int, error) {
+	cards := make([]int, 0, len(words))
+	listed := make(map[int]bool, len(words))
+	add := func(idx int) error {
+		if listed[idx] {
+			return fmt.Errorf("card %d is listed twice", idx+1)
+		}
+		listed[idx] = true
+		cards = append(cards, idx)
+		return nil
+	}
+
+	for _, word := range words {
+		if isPosition(word) {
+			first, last, err := parseRange(word, len(hand))
+			if err != nil {
+				return nil, err
+			}
+			for idx := first; idx <= last; idx++ {
+				if err := add(idx); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		rank, suit, ok := parseCardName(word)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a card position or name like AH or 10S", word)
+		}
+		found := -1
+		for idx, card := range hand {
+			if !listed[idx] && !card.FaceDown && card.HasRank() && card.Rank == rank && card.Suit == suit {
+				found = idx
+				break
+			}
+		}
+		if found < 0 {
+			return nil, fmt.Errorf("there is no %s%s in your hand", rank, suit)
+		}
+		add(found)
+	}
+
+	if len(cards) > MaxSelection {
+		return nil, fmt.Errorf("select at most %d cards", MaxSelection)
+	}
+	return cards, nil
+}
+
+// parseRange parses "3" or "1-3" into 0-based first and last positions
+func parseRange(word string, handSize int) (int, int, error) {
+	from, to, isRange := strings.Cut(word, "-")
+	if !isRange {
+		to = from
+	}
+	first, err := strconv.Atoi(from)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%q is not a card position", word)
+	}
+	last, err := strconv.Atoi(to)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%q is not a card range", word)
+	}
+	if first > last {
+		return 0, 0, fmt.Errorf("range %q runs backwards", word)
+	}
+	if first < 1 || last > handSize {
+		return 0, 0, fmt.Errorf("card %q is out of range, pick 1-%d", word, handSize)
+	}
+	return first - 1, last - 1, nil
+}
+
+// parseCardName parses names like "ah", "10s" or "td"
+func parseCardName(word string) (Rank, Suit, bool) {
+	if len(word) < 2 {
+		return 0, 0, false
+	}
+	rankName, suitName := word[:len(word)-1], word[len(word)-1]
+
+	var suit Suit
+	switch suitName {
+	case 'h':
+		suit = Hearts
+	case 'd':
+		suit = Diamonds
+	case 'c':
+		suit = Clubs
+	case 's':
+		suit = Spades
+	default:
+		return 0, 0, false
+	}
+
+	switch rankName {
+	case "a":
+		return Ace, suit, true
+	case "k":
+		return King, suit, true
+	case "q":
+		return Queen, suit, true
+	case "j":
+		return Jack, suit, true
+	case "t", "10":
+		return Ten, suit, true
+	}
+	n, err := strconv.Atoi(rankName)
+	if err != nil || n < 2 || n > 9 {
+		return 0, 0, false
+	}
+	return Rank(n), suit, true
+}
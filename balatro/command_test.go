@@ -0,0 +1,95 @@
+package balatro
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func commandHand() Hand {
+	return Hand{
+		{Suit: Hearts, Rank: Ace},
+		{Suit: Spades, Rank: King},
+		{Suit: Hearts, Rank: Seven},
+		{Suit: Clubs, Rank: Ten},
+		{Suit: Hearts, Rank: Seven},
+		{Suit: Diamonds, Rank: Two, FaceDown: true},
+		{Suit: Clubs, Rank: Four, Enhancement: StoneCard},
+		{Suit: Diamonds, Rank: Nine},
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Command
+	}{
+		{"3", Command{Kind: SelectCommand, Cards: []int{2}}},
+		{"1 3 5", Command{Kind: SelectCommand, Cards: []int{0, 2, 4}}},
+		{"1-3", Command{Kind: SelectCommand, Cards: []int{0, 1, 2}}},
+		{"AH ks", Command{Kind: SelectCommand, Cards: []int{0, 1}}},
+		{"7h 7H", Command{Kind: SelectCommand, Cards: []int{2, 4}}},
+		{"10c", Command{Kind: SelectCommand, Cards: []int{3}}},
+		{"TC 9d", Command{Kind: SelectCommand, Cards: []int{3, 7}}},
+		{"play 1 3 5", Command{Kind: PlayCommand, Cards: []int{0, 2, 4}}},
+		{"  PLAY  ", Command{Kind: PlayCommand, Cards: []int{}}},
+		{"discard 2 4", Command{Kind: DiscardCommand, Cards: []int{1, 3}}},
+		{"d 6-8", Command{Kind: DiscardCommand, Cards: []int{5, 6, 7}}},
+		{"sort rank", Command{Kind: SortCommand}},
+		{"sort suit", Command{Kind: SortCommand, BySuit: true}},
+		{"use 2", Command{Kind: UseCommand, Consumable: 1, Cards: []int{}}},
+		{"use 1 AH 2", Command{Kind: UseCommand, Consumable: 0, Cards: []int{0, 1}}},
+		{"suggest", Command{Kind: SuggestCommand}},
+		{"a", Command{Kind: SuggestCommand}},
+		{"deck", Command{Kind: DeckCommand}},
+		{"done", Command{Kind: DoneCommand}},
+		{"help", Command{Kind: HelpCommand}},
+	}
+	for _, tt := range tests {
+		got, err := ParseCommand(tt.input, commandHand())
+		if err != nil {
+			t.Errorf("ParseCommand(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("ParseCommand(%q) = %+v, want %+v", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestParseCommandErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		// message is part of the expected error
+		message string
+	}{
+		{"", "help"},
+		{"jump", "unknown command"},
+		{"s", "unknown command"},
+		{"9", "out of range"},
+		{"0", "out of range"},
+		{"3-1", "backwards"},
+		{"1-9", "out of range"},
+		{"play 1 1", "listed twice"},
+		{"play 1-2 2", "listed twice"},
+		{"1-6", "at most 5"},
+		{"QH", "no Q♥"},
+		{"2D", "no 2♦"},
+		{"4C", "no 4♣"},
+		{"play 1 XZ", "not a card position or name"},
+		{"sort colour", "sort rank|suit"},
+		{"use", "usage"},
+		{"use x", "not a consumable number"},
+		{"deck 2", "doesn't take any arguments"},
+	}
+	for _, tt := range tests {
+		_, err := ParseCommand(tt.input, commandHand())
+		if err == nil {
+			t.Errorf("ParseCommand(%q) should fail", tt.input)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.message) {
+			t.Errorf("ParseCommand(%q) error %q should mention %q", tt.input, err, tt.message)
+		}
+	}
+}
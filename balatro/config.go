@@ -0,0 +1,191 @@
+package balatro
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// DeckKind is the starting deck a run is played with
+type DeckKind int
+
+const (
+	StandardDeck DeckKind = iota
+	RedDeck
+	BlueDeck
+	AbandonedDeck
+	CheckeredDeck
+	ErraticDeck
+)
+
+var DeckKinds = []DeckKind{StandardDeck, RedDeck, BlueDeck, AbandonedDeck, CheckeredDeck, ErraticDeck}
+
+func (d DeckKind) String() string {
+	switch d {
+	case StandardDeck:
+		return "Standard"
+	case RedDeck:
+		return "Red"
+	case BlueDeck:
+		return "Blue"
+	case AbandonedDeck:
+		return "Abandoned"
+	case CheckeredDeck:
+		return "Checkered"
+	case ErraticDeck:
+		return "Erratic"
+	default:
+		return "Unknown"
+	}
+}
+
+func (d DeckKind) Description() string {
+	switch d {
+	case StandardDeck:
+		return "A plain 52 card deck"
+	case RedDeck:
+		return "+1 discard every round"
+	case BlueDeck:
+		return "+1 hand every round"
+	case AbandonedDeck:
+		return "Start with no face cards"
+	case CheckeredDeck:
+		return "Start with 26 Spades and 26 Hearts"
+	case ErraticDeck:
+		return "All ranks and suits are randomized"
+	default:
+		return ""
+	}
+}
+
+// build creates the starting full deck
+func (d DeckKind) build(rng *rand.Rand) *Deck {
+	switch d {
+	case AbandonedDeck:
+		deck := &Deck{Cards: make([]Card, 0, 40)}
+		for suit := Hearts; suit <= Spades; suit++ {
+			for rank := Two; rank <= Ace; rank++ {
+				if !rank.IsFace() {
+					deck.Add(Card{Suit: suit, Rank: rank})
+				}
+			}
+		}
+		return deck
+	case CheckeredDeck:
+		deck := &Deck{Cards: make([]Card, 0, 52)}
+		for _, suit := range []Suit{Hearts, Hearts, Spades, Spades} {
+			for rank := Two; rank <= Ace; rank++ {
+				deck.Add(Card{Suit: suit, Rank: rank})
+			}
+		}
+		return deck
+	case ErraticDeck:
+		deck := &Deck{Cards: make([]Card, 0, 52)}
+		for i := 0; i < 52; i++ {
+			deck.Add(Card{Suit: Suit(rng.Intn(4)), Rank: Two + Rank(rng.Intn(13))})
+		}
+		return deck
+	default:
+		return NewDeck()
+	}
+}
+
+// Stake is the difficulty of a run. Each stake includes the rules of every
+// stake below it.
+type Stake int
+
+const (
+	WhiteStake Stake = iota
+	RedStake
+	GreenStake
+	BlackStake
+	BlueStake
+	PurpleStake
+	OrangeStake
+)
+
+var Stakes = []Stake{WhiteStake, RedStake, GreenStake, BlackStake, BlueStake, PurpleStake, OrangeStake}
+
+func (s Stake) String() string {
+	switch s {
+	case WhiteStake:
+		return "White"
+	case RedStake:
+		return "Red"
+	case GreenStake:
+		return "Green"
+	case BlackStake:
+		return "Black"
+	case BlueStake:
+		return "Blue"
+	case PurpleStake:
+		return "Purple"
+	case OrangeStake:
+		return "Orange"
+	default:
+		return "Unknown"
+	}
+}
+
+// Description explains the rule the stake adds on top of the lower stakes
+func (s Stake) Description() string {
+	switch s {
+	case WhiteStake:
+		return "Base difficulty"
+	case RedStake:
+		return "Small blinds give no reward money"
+	case GreenStake:
+		return "Required score scales faster for each ante"
+	case BlackStake:
+		return "Shop can have Eternal jokers, which can't be sold"
+	case BlueStake:
+		return "-1 discard"
+	case PurpleStake:
+		return "Required score scales even faster for each ante"
+	case OrangeStake:
+		return "Shop can have Perishable jokers, debuffed after 5 rounds"
+	default:
+		return ""
+	}
+}
+
+// anteScores returns the small blind targets for antes 1 through 8
+func (s Stake) anteScores() []int {
+	switch {
+	case s >= PurpleStake:
+		return []int{300, 1000, 3200, 9000, 25000, 60000, 110000, 200000}
+	case s >= GreenStake:
+		return []int{300, 900, 2600, 8000, 20000, 36000, 60000, 100000}
+	default:
+		return anteBaseScores
+	}
+}
+
+// RunConfig holds the choices made when starting a run
+type RunConfig struct {
+	Deck  DeckKind
+	Stake Stake
+	// Seed drives every random event in the run, so runs with the same seed
+	// and the same choices play out the same. Empty picks a random seed.
+	Seed string
+}
+
+// ParseDeckKind looks up a deck by name, ignoring case
+func ParseDeckKind(name string) (DeckKind, error) {
+	for _, deck := range DeckKinds {
+		if strings.EqualFold(deck.String(), name) {
+			return deck, nil
+		}
+	}
+	return StandardDeck, fmt.Errorf("unknown deck %q", name)
+}
+
+// ParseStake looks up a stake by name, ignoring case
+func ParseStake(name string) (Stake, error) {
+	for _, stake := range Stakes {
+		if strings.EqualFold(stake.String(), name) {
+			return stake, nil
+		}
+	}
+	return WhiteStake, fmt.Errorf("unknown stake %q", name)
+}
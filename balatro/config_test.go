@@ -0,0 +1,108 @@
+package balatro
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDeckVariants(t *testing.T) {
+	tests := []struct {
+		deck     DeckKind
+		size     int
+		hands    int
+		discards int
+		check    func(Card) bool
+	}{
+		{StandardDeck, 52, 4, 3, func(Card) bool { return true }},
+		{RedDeck, 52, 4, 4, func(Card) bool { return true }},
+		{BlueDeck, 52, 5, 3, func(Card) bool { return true }},
+		{AbandonedDeck, 40, 4, 3, func(c Card) bool { return !c.IsFace() }},
+		{CheckeredDeck, 52, 4, 3, func(c Card) bool { return c.Suit == Spades || c.Suit == Hearts }},
+		{ErraticDeck, 52, 4, 3, func(Card) bool { return true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.deck.String(), func(t *testing.T) {
+			g := NewGame(RunConfig{Deck: tt.deck, Seed: "SEED99"})
+			if len(g.FullDeck.Cards) != tt.size {
+				t.Errorf("Expected %d cards, got %d", tt.size, len(g.FullDeck.Cards))
+			}
+			if g.Hands != tt.hands || g.Discards != tt.discards {
+				t.Errorf("Expected %d hands and %d discards, got %d and %d", tt.hands, tt.discards, g.Hands, g.Discards)
+			}
+			for _, card := range g.FullDeck.Cards {
+				if !tt.check(card) {
+					t.Errorf("Unexpected card %s", card)
+				}
+			}
+		})
+	}
+
+	first := NewGame(RunConfig{Deck: ErraticDeck, Seed: "SEED5"}).FullDeck.Cards
+	second := NewGame(RunConfig{Deck: ErraticDeck, Seed: "SEED5"}).FullDeck.Cards
+	for idx := range first {
+		if first[idx] != second[idx] {
+			t.Fatalf("Erratic decks from the same seed differ at card %d: %s vs %s", idx, first[idx], second[idx])
+		}
+	}
+}
+
+func TestBlueStakeRemovesDiscard(t *testing.T) {
+	g := NewGame(RunConfig{Deck: RedDeck, Stake: BlueStake, Seed: "SEED1"})
+	if g.Discards != 3 {
+		t.Errorf("Expected the Red deck's extra discard to cancel the Blue stake, got %d discards", g.Discards)
+	}
+	g = NewGame(RunConfig{Stake: PurpleStake, Seed: "SEED1"})
+	if g.Discards != 2 {
+		t.Errorf("Expected stakes above Blue to keep the lost discard, got %d discards", g.Discards)
+	}
+}
+
+func TestJokerStickers(t *testing.T) {
+	g := NewGame(RunConfig{Seed: "SEED2"})
+	g.Jokers = []Joker{{Kind: PlainJoker, Eternal: true}, {Kind: GoldenJoker, Perishable: true, RoundsLeft: 1}}
+
+	if _, err := g.SellJoker(0); err == nil {
+		t.Error("Expected selling an Eternal joker to fail")
+	}
+
+	payout := g.CashOut()
+	if payout.Jokers != 4 {
+		t.Errorf("Expected the Golden Joker to pay on its last round, got $%d", payout.Jokers)
+	}
+	if !g.Jokers[1].Debuffed() {
+		t.Errorf("Expected the Perishable joker to perish, got %s", g.Jokers[1])
+	}
+	eval := EvaluateHandWithContext(Hand{{Suit: Hearts, Rank: Two}}, ScoringContext{Jokers: []Joker{g.Jokers[1], {Kind: Cavendish, Perishable: true}}})
+	if eval.Mult != 1 {
+		t.Errorf("Expected perished jokers to do nothing, got mult %.1f", eval.Mult)
+	}
+
+	sawEternal, sawPerishable := false, false
+	for seed := int64(0); seed < 200; seed++ {
+		joker := randomJoker(rand.New(rand.NewSource(seed)), OrangeStake)
+		sawEternal = sawEternal || joker.Eternal
+		sawPerishable = sawPerishable || joker.Perishable
+		if joker.Eternal && joker.Perishable {
+			t.Fatalf("A joker can't be both Eternal and Perishable: %s", joker)
+		}
+		if white := randomJoker(rand.New(rand.NewSource(seed)), WhiteStake); white.Eternal || white.Perishable {
+			t.Fatalf("White stake jokers shouldn't have stickers: %s", white)
+		}
+	}
+	if !sawEternal || !sawPerishable {
+		t.Error("Expected the Orange stake to roll both Eternal and Perishable jokers")
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	if deck, err := ParseDeckKind("checkered"); err != nil || deck != CheckeredDeck {
+		t.Errorf("Expected the Checkered deck, got %s, %v", deck, err)
+	}
+	if _, err := ParseDeckKind("Plasma"); err == nil {
+		t.Error("Expected an unknown deck to fail")
+	}
+	if stake, err := ParseStake("ORANGE"); err != nil || stake != OrangeStake {
+		t.Errorf("Expected the Orange stake, got %s, %v", stake, err)
+	}
+}
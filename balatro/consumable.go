@@ -0,0 +1,519 @@
+package balatro
+
+import (
+	"fmt"
+)
+
+// MaxConsumables is the number of consumable slots
+const MaxConsumables = 2
+
+type ConsumableCategory int
+
+const (
+	PlanetCategory ConsumableCategory = iota
+	TarotCategory
+	SpectralCategory
+)
+
+func (c ConsumableCategory) String() string {
+	switch c {
+	case PlanetCategory:
+		return "Planet"
+	case TarotCategory:
+		return "Tarot"
+	case SpectralCategory:
+		return "Spectral"
+	default:
+		return "Unknown"
+	}
+}
+
+type ConsumableKind int
+
+const (
+	// Planets, one per hand type they level up
+	Pluto ConsumableKind = iota
+	Mercury
+	Uranus
+	Venus
+	Saturn
+	Jupiter
+	Earth
+	Mars
+	Neptune
+
+	// Tarots
+	TheHermit
+	Temperance
+	TheStar
+	TheMoon
+	TheSun
+	TheWorld
+	TheEmpress
+	TheHierophant
+	TheLovers
+	TheChariot
+	Justice
+	TheDevil
+	TheTower
+	TheMagician
+	TheHangedMan
+	Strength
+	Death
+
+	// Spectrals
+	Cryptid
+	Talisman
+	DejaVu
+	Trance
+	Medium
+	Aura
+	Immolate
+
+	// Planets for the secret hands
+	PlanetX
+	Ceres
+	Eris
+)
+
+// PlanetKinds lists the planet cards, in hand type order
+var PlanetKinds = []ConsumableKind{Pluto, Mercury, Uranus, Venus, Saturn, Jupiter, Earth, Mars, Neptune}
+
+// SecretPlanetKinds level up the secret hands. The shop doesn't stock them,
+// they come from Blue Seals held after playing a secret hand.
+var SecretPlanetKinds = []ConsumableKind{PlanetX, Ceres, Eris}
+
+// TarotKinds lists the tarot cards the shop can offer
+var TarotKinds = []ConsumableKind{
+	TheHermit, Temperance, TheStar, TheMoon, TheSun, TheWorld, TheEmpress, TheHierophant,
+	TheLovers, TheChariot, Justice, TheDevil, TheTower, TheMagician, TheHangedMan, Strength, Death,
+}
+
+// SpectralKinds lists the spectral cards found in Spectral packs
+var SpectralKinds = []ConsumableKind{Cryptid, Talisman, DejaVu, Trance, Medium, Aura, Immolate}
+
+func (k ConsumableKind) String() string {
+	switch k {
+	case Pluto:
+		return "Pluto"
+	case Mercury:
+		return "Mercury"
+	case Uranus:
+		return "Uranus"
+	case Venus:
+		return "Venus"
+	case Saturn:
+		return "Saturn"
+	case Jupiter:
+		return "Jupiter"
+	case Earth:
+		return "Earth"
+	case Mars:
+		return "Mars"
+	case Neptune:
+		return "Neptune"
+	case TheHermit:
+		return "The Hermit"
+	case Temperance:
+		return "Temperance"
+	case TheStar:
+		return "The Star"
+	case TheMoon:
+		return "The Moon"
+	case TheSun:
+		return "The Sun"
+	case TheWorld:
+		return "The World"
+	case TheEmpress:
+		return "The Empress"
+	case TheHierophant:
+		return "The Hierophant"
+	case TheLovers:
+		return "The Lovers"
+	case TheChariot:
+		return "The Chariot"
+	case Justice:
+		return "Justice"
+	case TheDevil:
+		return "The Devil"
+	case TheTower:
+		return "The Tower"
+	case TheMagician:
+		return "The Magician"
+	case TheHangedMan:
+		return "The Hanged Man"
+	case Strength:
+		return "Strength"
+	case Death:
+		return "Death"
+	case Cryptid:
+		return "Cryptid"
+	case Talisman:
+		return "Talisman"
+	case DejaVu:
+		return "Deja Vu"
+	case Trance:
+		return "Trance"
+	case Medium:
+		return "Medium"
+	case Aura:
+		return "Aura"
+	case Immolate:
+		return "Immolate"
+	case PlanetX:
+		return "Planet X"
+	case Ceres:
+		return "Ceres"
+	case Eris:
+		return "Eris"
+	default:
+		return "Unknown"
+	}
+}
+
+func (k ConsumableKind) Category() ConsumableCategory {
+	switch {
+	case k <= Neptune, k >= PlanetX:
+		return PlanetCategory
+	case k >= Cryptid:
+		return SpectralCategory
+	default:
+		return TarotCategory
+	}
+}
+
+// PlanetHandType returns the hand type a planet card levels up
+func (k ConsumableKind) PlanetHandType() HandType {
+	switch k {
+	case Pluto:
+		return HighCard
+	case Mercury:
+		return Pair
+	case Uranus:
+		return TwoPair
+	case Venus:
+		return ThreeOfAKind
+	case Saturn:
+		return Straight
+	case Jupiter:
+		return Flush
+	case Earth:
+		return FullHouse
+	case Mars:
+		return FourOfAKind
+	case Neptune:
+		return StraightFlush
+	case PlanetX:
+		return FiveOfAKind
+	case Ceres:
+		return FlushHouse
+	case Eris:
+		return FlushFive
+	default:
+		return HighCard
+	}
+}
+
+// PlanetFor returns the planet card that levels up the hand type
+func PlanetFor(handType HandType) ConsumableKind {
+	planets := append(append([]ConsumableKind{}, PlanetKinds...), SecretPlanetKinds...)
+	for _, planet := range planets {
+		if planet.PlanetHandType() == handType {
+			return planet
+		}
+	}
+	return Neptune
+}
+
+func (k ConsumableKind) Description() string {
+	if k.Category() == PlanetCategory {
+		return fmt.Sprintf("Level up %s", k.PlanetHandType())
+	}
+	switch k {
+	case TheHermit:
+		return "Double your money (max $20)"
+	case Temperance:
+		return "Gain the total sell value of your jokers (max $50)"
+	case TheStar, TheMoon, TheSun, TheWorld:
+		return fmt.Sprintf("Convert up to 3 selected cards to %s", k.targetSuit())
+	case TheEmpress, TheHierophant, TheLovers, TheChariot, Justice, TheDevil, TheTower, TheMagician:
+		return fmt.Sprintf("Enhance up to %d selected cards to %s cards", k.MaxTargets(), k.targetEnhancement())
+	case TheHangedMan:
+		return "Destroy up to 2 selected cards"
+	case Strength:
+		return "Increase the rank of up to 2 selected cards by 1"
+	case Death:
+		return "Select 2 cards, convert the left card into the right card"
+	case Cryptid:
+		return "Create 2 copies of 1 selected card"
+	case Talisman:
+		return "Add a Gold Seal to 1 selected card"
+	case DejaVu:
+		return "Add a Red Seal to 1 selected card"
+	case Trance:
+		return "Add a Blue Seal to 1 selected card"
+	case Medium:
+		return "Add a Purple Seal to 1 selected card"
+	case Aura:
+		return "Add Foil, Holographic or Polychrome to 1 selected card"
+	case Immolate:
+		return "Destroy 5 random cards in hand, gain $20"
+	default:
+		return ""
+	}
+}
+
+// GetCost returns the shop price of the consumable
+func (k ConsumableKind) GetCost() int {
+	if k.Category() == SpectralCategory {
+		return 4
+	}
+	return 3
+}
+
+// MinTargets and MaxTargets bound how many cards in hand the consumable
+// must be used on. Consumables that affect the whole run take no targets.
+func (k ConsumableKind) MinTargets() int {
+	switch k {
+	case Death:
+		return 2
+	case TheStar, TheMoon, TheSun, TheWorld, TheEmpress, TheHierophant, TheLovers, TheChariot,
+		Justice, TheDevil, TheTower, TheMagician, TheHangedMan, Strength,
+		Cryptid, Talisman, DejaVu, Trance, Medium, Aura:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (k ConsumableKind) MaxTargets() int {
+	switch k {
+	case TheStar, TheMoon, TheSun, TheWorld:
+		return 3
+	case TheEmpress, TheHierophant, TheMagician, TheHangedMan, Strength, Death:
+		return 2
+	case TheLovers, TheChariot, Justice, TheDevil, TheTower,
+		Cryptid, Talisman, DejaVu, Trance, Medium, Aura:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// NeedsHand reports whether the consumable works on cards in hand, so it can
+// only be used during a blind
+func (k ConsumableKind) NeedsHand() bool {
+	return k.MinTargets() > 0 || k == Immolate
+}
+
+func (k ConsumableKind) targetSuit() Suit {
+	switch k {
+	case TheStar:
+		return Diamonds
+	case TheMoon:
+		return Clubs
+	case TheSun:
+		return Hearts
+	default:
+		return Spades
+	}
+}
+
+func (k ConsumableKind) targetEnhancement() Enhancement {
+	switch k {
+	case TheEmpress:
+		return MultCard
+	case TheHierophant:
+		return BonusCard
+	case TheLovers:
+		return WildCard
+	case TheChariot:
+		return SteelCard
+	case Justice:
+		return GlassCard
+	case TheDevil:
+		return GoldCard
+	case TheTower:
+		return StoneCard
+	case TheMagician:
+		return LuckyCard
+	default:
+		return NoEnhancement
+	}
+}
+
+func (k ConsumableKind) targetSeal() Seal {
+	switch k {
+	case Talisman:
+		return GoldSeal
+	case DejaVu:
+		return RedSeal
+	case Trance:
+		return BlueSeal
+	case Medium:
+		return PurpleSeal
+	default:
+		return NoSeal
+	}
+}
+
+type Consumable struct {
+	Kind ConsumableKind
+}
+
+func (c Consumable) String() string {
+	return fmt.Sprintf("%s (%s)", c.Kind, c.Kind.Category())
+}
+
+// Use applies the consumable to the run. Targets are indexes into the hand
+// and must match the consumable's MinTargets and MaxTargets. It returns a
+// description of what happened.
+func (c Consumable) Use(g *Game, targets []int) (string, error) {
+	kind := c.Kind
+	if len(targets) < kind.MinTargets() || len(targets) > kind.MaxTargets() {
+		if kind.MaxTargets() == 0 {
+			return "", fmt.Errorf("%s does not take any cards", kind)
+		}
+		return "", fmt.Errorf("%s needs %d to %d selected cards", kind, kind.MinTargets(), kind.MaxTargets())
+	}
+	if kind.NeedsHand() && (g.Shop != nil || len(g.PlayerHand) == 0) {
+		return "", fmt.Errorf("%s can only be used on cards in hand during a blind", kind)
+	}
+	if _, _, err := g.splitHand(targets); len(targets) > 0 && err != nil {
+		return "", err
+	}
+
+	if kind.Category() == PlanetCategory {
+		handType := kind.PlanetHandType()
+		g.HandLevels.LevelUp(handType)
+		return fmt.Sprintf("%s is now level %d", handType, g.HandLevels.Level(handType)), nil
+	}
+
+	switch kind {
+	case TheHermit:
+		gain := g.Money
+		if gain > 20 {
+			gain = 20
+		}
+		if gain < 0 {
+			gain = 0
+		}
+		g.Money += gain
+		return fmt.Sprintf("Gained $%d", gain), nil
+	case Temperance:
+		gain := 0
+		for _, joker := range g.Jokers {
+			gain += joker.GetSellValue()
+		}
+		if gain > 50 {
+			gain = 50
+		}
+		g.Money += gain
+		return fmt.Sprintf("Gained $%d", gain), nil
+	case TheStar, TheMoon, TheSun, TheWorld:
+		suit := kind.targetSuit()
+		g.updateCards(targets, func(card *Card) { card.Suit = suit })
+		return fmt.Sprintf("Converted %d cards to %s", len(targets), suit), nil
+	case TheEmpress, TheHierophant, TheLovers, TheChariot, Justice, TheDevil, TheTower, TheMagician:
+		enhancement := kind.targetEnhancement()
+		g.updateCards(targets, func(card *Card) { card.Enhancement = enhancement })
+		return fmt.Sprintf("Enhanced %d cards to %s", len(targets), enhancement), nil
+	case Talisman, DejaVu, Trance, Medium:
+		seal := kind.targetSeal()
+		g.updateCards(targets, func(card *Card) { card.Seal = seal })
+		return fmt.Sprintf("Added a %s", seal), nil
+	case Aura:
+		edition := []Edition{Foil, Holographic, Polychrome}[g.rng.Intn(3)]
+		g.updateCards(targets, func(card *Card) { card.Edition = edition })
+		return fmt.Sprintf("Added %s", edition), nil
+	case Strength:
+		g.updateCards(targets, func(card *Card) {
+			if card.Rank == Ace {
+				card.Rank = Two
+			} else {
+				card.Rank++
+			}
+		})
+		return fmt.Sprintf("Ranked up %d cards", len(targets)), nil
+	case Death:
+		// left and right are positions in the hand, not the order they were
+		// selected in
+		left, rightIdx := targets[0], targets[1]
+		if left > rightIdx {
+			left, rightIdx = rightIdx, left
+		}
+		right := g.PlayerHand[rightIdx]
+		if right.FaceDown {
+			return "", fmt.Errorf("%s can't copy a face down card", kind)
+		}
+		g.updateCards([]int{left}, func(card *Card) {
+			id := card.ID
+			*card = right
+			card.ID = id
+			// the copy is of a card the player has seen
+			card.FaceDown = false
+		})
+		return fmt.Sprintf("Converted a card into %s", right), nil
+	case TheHangedMan:
+		destroyed := g.destroyCards(targets)
+		return fmt.Sprintf("Destroyed %s", destroyed), nil
+	case Cryptid:
+		original := g.PlayerHand[targets[0]]
+		for i := 0; i < 2; i++ {
+			g.PlayerHand = append(g.PlayerHand, g.FullDeck.Add(original))
+		}
+		return fmt.Sprintf("Created 2 copies of %s", original), nil
+	case Immolate:
+		count := 5
+		if count > len(g.PlayerHand) {
+			count = len(g.PlayerHand)
+		}
+		destroyed := g.destroyCards(g.rng.Perm(len(g.PlayerHand))[:count])
+		g.Money += 20
+		return fmt.Sprintf("Destroyed %s and gained $20", destroyed), nil
+	default:
+		return "Nothing happened", nil
+	}
+}
+
+// updateCards changes cards in hand and the matching cards in the full deck,
+// so the change lasts for the rest of the run
+func (g *Game) updateCards(indices []int, update func(card *Card)) {
+	for _, idx := range indices {
+		update(&g.PlayerHand[idx])
+		g.FullDeck.Replace(g.PlayerHand[idx])
+	}
+}
+
+// destroyCards removes cards from the hand and the full deck
+func (g *Game) destroyCards(indices []int) Hand {
+	destroyed, held, _ := g.splitHand(indices)
+	for _, card := range destroyed {
+		g.FullDeck.Remove(card.ID)
+	}
+	g.PlayerHand = held
+	return destroyed
+}
+
+// UseConsumable uses the consumable in the given slot on the targeted cards in hand
+func (g *Game) UseConsumable(idx int, targets []int) (string, error) {
+	if idx < 0 || idx >= len(g.Consumables) {
+		return "", fmt.Errorf("there is no consumable %d", idx+1)
+	}
+	consumable := g.Consumables[idx]
+	message, err := consumable.Use(g, targets)
+	if err != nil {
+		return "", err
+	}
+	g.Consumables = append(g.Consumables[:idx], g.Consumables[idx+1:]...)
+	return message, nil
+}
+
+// gainConsumable fills an empty consumable slot, returning false if they are all taken
+func (g *Game) gainConsumable(kind ConsumableKind) bool {
+	if len(g.Consumables) >= MaxConsumables {
+		return false
+	}
+	g.Consumables = append(g.Consumables, Consumable{Kind: kind})
+	return true
+}
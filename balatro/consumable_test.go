@@ -0,0 +1,150 @@
+package balatro
+
+import (
+	"testing"
+)
+
+func countInDeck(deck *Deck, match func(Card) bool) int {
+	count := 0
+	for _, card := range deck.Cards {
+		if match(card) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestTarotChangesPersistAcrossBlinds(t *testing.T) {
+	g := newTestGame(11)
+	g.Consumables = []Consumable{{Kind: TheSun}, {Kind: TheEmpress}}
+
+	converted := []Card{g.PlayerHand[0], g.PlayerHand[1]}
+	if _, err := g.UseConsumable(0, []int{0, 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := g.UseConsumable(0, []int{0}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(g.Consumables) != 0 {
+		t.Errorf("Expected the consumables to be used up, got %v", g.Consumables)
+	}
+
+	g.NextBlind()
+	for idx, original := range converted {
+		found := false
+		for _, card := range g.FullDeck.Cards {
+			if card.ID != original.ID {
+				continue
+			}
+			found = true
+			if card.Suit != Hearts {
+				t.Errorf("Expected card %d to stay a heart, got %s", idx, card)
+			}
+			if idx == 0 && card.Enhancement != MultCard {
+				t.Errorf("Expected the first card to stay a Mult card, got %s", card)
+			}
+		}
+		if !found {
+			t.Errorf("Card %s is missing from the full deck", original)
+		}
+	}
+	if len(g.Deck.Cards)+len(g.PlayerHand) != 52 {
+		t.Errorf("Expected the new draw pile and hand to hold 52 cards, got %d", len(g.Deck.Cards)+len(g.PlayerHand))
+	}
+}
+
+func TestHangedManAndCryptid(t *testing.T) {
+	g := newTestGame(12)
+	g.Consumables = []Consumable{{Kind: TheHangedMan}, {Kind: Cryptid}}
+
+	destroyed := g.PlayerHand[2]
+	if _, err := g.UseConsumable(0, []int{2}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(g.FullDeck.Cards) != 51 || len(g.PlayerHand) != HandSize-1 {
+		t.Errorf("Expected 51 cards in the deck and %d in hand, got %d and %d", HandSize-1, len(g.FullDeck.Cards), len(g.PlayerHand))
+	}
+	if countInDeck(g.FullDeck, func(c Card) bool { return c.ID == destroyed.ID }) != 0 {
+		t.Errorf("Expected %s to be destroyed", destroyed)
+	}
+
+	copied := g.PlayerHand[0]
+	if _, err := g.UseConsumable(0, []int{0}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	same := func(c Card) bool { return c.Suit == copied.Suit && c.Rank == copied.Rank }
+	if count := countInDeck(g.FullDeck, same); count != 3 {
+		t.Errorf("Expected 3 copies of %s in the deck, got %d", copied, count)
+	}
+	if len(g.PlayerHand) != HandSize+1 {
+		t.Errorf("Expected the copies in hand, got %s", Hand(g.PlayerHand))
+	}
+}
+
+func TestConsumableTargets(t *testing.T) {
+	g := newTestGame(13)
+	g.Consumables = []Consumable{{Kind: Death}, {Kind: TheHermit}}
+
+	if _, err := g.UseConsumable(0, []int{0}); err == nil {
+		t.Error("Expected Death with one target to fail")
+	}
+	if _, err := g.UseConsumable(1, []int{0}); err == nil {
+		t.Error("Expected The Hermit with a target to fail")
+	}
+
+	right := g.PlayerHand[1]
+	if _, err := g.UseConsumable(0, []int{0, 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if g.PlayerHand[0].Rank != right.Rank || g.PlayerHand[0].Suit != right.Suit || g.PlayerHand[0].ID == right.ID {
+		t.Errorf("Expected %s to become a copy of %s", g.PlayerHand[0], right)
+	}
+
+	// the left card is converted however the cards were selected
+	g.Consumables = append([]Consumable{{Kind: Death}}, g.Consumables...)
+	right = g.PlayerHand[3]
+	if _, err := g.UseConsumable(0, []int{3, 2}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if g.PlayerHand[2].Rank != right.Rank || g.PlayerHand[2].Suit != right.Suit {
+		t.Errorf("Expected %s to become a copy of %s", g.PlayerHand[2], right)
+	}
+	if g.PlayerHand[3] != right {
+		t.Errorf("Expected %s to be left alone, got %s", right, g.PlayerHand[3])
+	}
+
+	// a face down card can be converted, but can't be copied
+	g.Consumables = append([]Consumable{{Kind: Death}}, g.Consumables...)
+	g.PlayerHand[4].FaceDown = true
+	if _, err := g.UseConsumable(0, []int{3, 4}); err == nil {
+		t.Error("Expected Death to refuse to copy a face down card")
+	}
+	if _, err := g.UseConsumable(0, []int{4, 5}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if g.PlayerHand[4].FaceDown {
+		t.Error("Expected the converted card to be face up")
+	}
+
+	g.Shop = newShop(g.rng, WhiteStake)
+	g.Consumables = append(g.Consumables, Consumable{Kind: TheStar})
+	if _, err := g.UseConsumable(1, []int{0}); err == nil {
+		t.Error("Expected The Star to need a blind")
+	}
+}
+
+func TestStrengthWrapsAces(t *testing.T) {
+	g := newTestGame(14)
+	g.PlayerHand[0].Rank = Ace
+	g.FullDeck.Replace(g.PlayerHand[0])
+	g.PlayerHand[1].Rank = Nine
+	g.FullDeck.Replace(g.PlayerHand[1])
+	g.Consumables = []Consumable{{Kind: Strength}}
+
+	if _, err := g.UseConsumable(0, []int{0, 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if g.PlayerHand[0].Rank != Two || g.PlayerHand[1].Rank != Ten {
+		t.Errorf("Expected a Two and a Ten, got %s", Hand(g.PlayerHand[:2]))
+	}
+}
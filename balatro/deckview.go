@@ -0,0 +1,103 @@
+package balatro
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DeckReport counts what a set of cards is made of
+type DeckReport struct {
+	Total int
+	// BySuit holds the ranked cards of each suit, highest first. Stone cards
+	// have no suit and are only counted in Stone.
+	BySuit       map[Suit]Hand
+	Stone        int
+	Face         int
+	Enhancements map[Enhancement]int
+	Editions     map[Edition]int
+	Seals        map[Seal]int
+}
+
+func NewDeckReport(cards []Card) DeckReport {
+	report := DeckReport{
+		Total:        len(cards),
+		BySuit:       map[Suit]Hand{},
+		Enhancements: map[Enhancement]int{},
+		Editions:     map[Edition]int{},
+		Seals:        map[Seal]int{},
+	}
+	for _, card := range cards {
+		if card.HasRank() {
+			report.BySuit[card.Suit] = append(report.BySuit[card.Suit], card)
+		} else {
+			report.Stone++
+		}
+		if card.IsFace() {
+			report.Face++
+		}
+		if card.Enhancement != NoEnhancement {
+			report.Enhancements[card.Enhancement]++
+		}
+		if card.Edition != NoEdition {
+			report.Editions[card.Edition]++
+		}
+		if card.Seal != NoSeal {
+			report.Seals[card.Seal]++
+		}
+	}
+	for _, hand := range report.BySuit {
+		sort.SliceStable(hand, func(i, j int) bool { return hand[i].Rank > hand[j].Rank })
+	}
+	return report
+}
+
+// DeckView describes the draw pile against the full deck: the cards left to
+// draw in each suit, and counts of face cards, enhancements, editions and seals
+func (g *Game) DeckView() string {
+	pile := NewDeckReport(g.Deck.Cards)
+	full := NewDeckReport(g.FullDeck.Cards)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Draw pile: %d of %d cards (%d in hand, %d played or discarded)\n",
+		pile.Total, full.Total, len(g.PlayerHand), full.Total-pile.Total-len(g.PlayerHand))
+	for suit := Hearts; suit <= Spades; suit++ {
+		ranks := make([]string, 0, len(pile.BySuit[suit]))
+		for _, card := range pile.BySuit[suit] {
+			ranks = append(ranks, card.Rank.String())
+		}
+		fmt.Fprintf(&b, "  %s %2d/%-2d %s\n", suit, len(pile.BySuit[suit]), len(full.BySuit[suit]), strings.Join(ranks, " "))
+	}
+	if full.Stone > 0 {
+		fmt.Fprintf(&b, "  Stone %d/%d\n", pile.Stone, full.Stone)
+	}
+	fmt.Fprintf(&b, "Face cards: %d/%d\n", pile.Face, full.Face)
+
+	var enhancements, editions, seals []string
+	for e := BonusCard; e <= LuckyCard; e++ {
+		if full.Enhancements[e] > 0 {
+			enhancements = append(enhancements, fmt.Sprintf("%s %d/%d", e, pile.Enhancements[e], full.Enhancements[e]))
+		}
+	}
+	for e := Foil; e <= Polychrome; e++ {
+		if full.Editions[e] > 0 {
+			editions = append(editions, fmt.Sprintf("%s %d/%d", e, pile.Editions[e], full.Editions[e]))
+		}
+	}
+	for s := GoldSeal; s <= PurpleSeal; s++ {
+		if full.Seals[s] > 0 {
+			seals = append(seals, fmt.Sprintf("%s %d/%d", s, pile.Seals[s], full.Seals[s]))
+		}
+	}
+	writeCounts(&b, "Enhancements", enhancements)
+	writeCounts(&b, "Editions", editions)
+	writeCounts(&b, "Seals", seals)
+	return b.String()
+}
+
+// writeCounts adds a line of draw pile/full deck counts, if there are any
+func writeCounts(b *strings.Builder, label string, counts []string) {
+	if len(counts) > 0 {
+		fmt.Fprintf(b, "%s: %s\n", label, strings.Join(counts, ", "))
+	}
+}
@@ -0,0 +1,42 @@
+package balatro
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDeckReport(t *testing.T) {
+	report := NewDeckReport([]Card{
+		{Suit: Hearts, Rank: Four},
+		{Suit: Hearts, Rank: King, Enhancement: GlassCard, Edition: Foil},
+		{Suit: Spades, Rank: Ace, Seal: RedSeal},
+		{Suit: Clubs, Rank: Two, Enhancement: StoneCard},
+	})
+
+	if report.Total != 4 || report.Stone != 1 || report.Face != 1 {
+		t.Errorf("Expected 4 cards, 1 stone and 1 face card, got %+v", report)
+	}
+	if hearts := report.BySuit[Hearts]; len(hearts) != 2 || hearts[0].Rank != King {
+		t.Errorf("Expected the hearts highest first, got %s", hearts)
+	}
+	if len(report.BySuit[Clubs]) != 0 {
+		t.Errorf("Expected the stone card to have no suit, got %s", report.BySuit[Clubs])
+	}
+	if report.Enhancements[GlassCard] != 1 || report.Editions[Foil] != 1 || report.Seals[RedSeal] != 1 {
+		t.Errorf("Expected one glass, foil and red seal card, got %+v", report)
+	}
+}
+
+func TestDeckViewTracksDrawPile(t *testing.T) {
+	g := newTestGame(37)
+	g.PlayHand([]int{0, 1})
+
+	view := g.DeckView()
+	expected := "Draw pile: 42 of 52 cards (8 in hand, 2 played or discarded)"
+	if !strings.HasPrefix(view, expected) {
+		t.Errorf("Expected the view to start with %q, got:\n%s", expected, view)
+	}
+	if !strings.Contains(view, "Face cards: ") {
+		t.Errorf("Expected a face card count, got:\n%s", view)
+	}
+}
@@ -3,140 +3,757 @@ package balatro
 import (
 	"bufio"
 	"fmt"
+	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 )
 
+const (
+	HandSize     = 8
+	MaxSelection = 5
+
+	startingHands    = 4
+	startingDiscards = 3
+	startingMoney    = 4
+
+	// suggestions is how many hands the suggest command lists
+	suggestions = 3
+)
+
 type Game struct {
+	// FullDeck is every card the run owns. Deck is the draw pile for the
+	// current blind, shuffled from the full deck when the blind starts.
+	FullDeck   *Deck
 	Deck       *Deck
 	PlayerHand []Card
 	Score      int
 	Round      int
+
+	Ante        int
+	Blind       BlindKind
+	Hands       int
+	Discards    int
+	Money       int
+	Jokers      []Joker
+	Consumables []Consumable
+	HandLevels  HandLevels
+	// LastHandType is the type of the last hand played, used by Blue Seals
+	LastHandType HandType
+	// Boss is the boss blind waiting at the end of the current ante
+	Boss BossKind
+	// PlayedHands are the hand types played so far in the current blind
+	PlayedHands []HandType
+	// Shop is open between a cleared blind and the next one
+	Shop   *Shop
+	Config RunConfig
+	Seed   string
+	Over   bool
+	Won    bool
+	// SavePath is where the run is saved after every action. Empty disables saving.
+	SavePath string
+
+	rng    *rand.Rand
+	source *rngSource
 }
 
-func NewGame() *Game {
-	deck := NewDeck()
-	deck.Shuffle()
-	
-	return &Game{
-		Deck:       deck,
+// NewGame starts a run with the chosen deck and stake
+func NewGame(config RunConfig) *Game {
+	seed := config.Seed
+	if seed == "" {
+		seed = RandomSeed()
+	}
+	config.Seed = seed
+	g := &Game{
+		Config:     config,
 		PlayerHand: make([]Card, 0),
 		Score:      0,
 		Round:      1,
+		Ante:       1,
+		Blind:      SmallBlind,
+		Money:      startingMoney,
+		HandLevels: HandLevels{},
+		Seed:       seed,
+	}
+	g.rng, g.source = newRand(seedNumber(seed))
+	g.FullDeck = config.Deck.build(g.rng)
+	g.Boss = g.randomBoss()
+	g.StartBlind()
+
+	return g
+}
+
+// ActiveBoss returns the boss whose rule applies right now, which is NoBoss
+// outside of boss blinds
+func (g *Game) ActiveBoss() BossKind {
+	if g.Blind != BossBlind {
+		return NoBoss
+	}
+	return g.Boss
+}
+
+func (g *Game) randomBoss() BossKind {
+	return BossKinds[g.rng.Intn(len(BossKinds))]
+}
+
+// Target returns the score needed to clear the current blind
+func (g *Game) Target() int {
+	return g.Blind.GetTarget(g.Ante, g.Config.Stake)
+}
+
+// MaxHands is the number of hands each blind starts with
+func (g *Game) MaxHands() int {
+	if g.Config.Deck == BlueDeck {
+		return startingHands + 1
+	}
+	return startingHands
+}
+
+// MaxDiscards is the number of discards each blind starts with
+func (g *Game) MaxDiscards() int {
+	discards := startingDiscards
+	if g.Config.Deck == RedDeck {
+		discards++
+	}
+	if g.Config.Stake >= BlueStake {
+		discards--
+	}
+	return discards
+}
+
+// StartBlind shuffles the full deck into a new draw pile and deals a hand
+// for the current blind
+func (g *Game) StartBlind() {
+	g.Deck = g.FullDeck.Clone()
+	g.Deck.Shuffle(g.rng)
+	g.PlayerHand = make([]Card, 0, HandSize)
+	g.Score = 0
+	g.Hands = g.MaxHands()
+	g.Discards = g.MaxDiscards()
+	g.PlayedHands = nil
+	g.Shop = nil
+	g.drawToHandSize(true)
+}
+
+// drawToHandSize refills the hand from the draw pile, turning cards face
+// down when the boss blind says so
+func (g *Game) drawToHandSize(firstHand bool) {
+	missing := HandSize - len(g.PlayerHand)
+	if missing <= 0 {
+		return
+	}
+	for _, card := range g.Deck.Draw(missing) {
+		card.FaceDown = g.ActiveBoss().DealsFaceDown(card, firstHand)
+		g.PlayerHand = append(g.PlayerHand, card)
+	}
+}
+
+// splitHand validates the selected indexes and splits the hand into the
+// selected cards and the ones left behind
+func (g *Game) splitHand(indices []int) (Hand, Hand, error) {
+	if len(indices) == 0 {
+		return nil, nil, fmt.Errorf("select at least one card")
+	}
+	if len(indices) > MaxSelection {
+		return nil, nil, fmt.Errorf("select at most %d cards", MaxSelection)
+	}
+
+	chosen := make(map[int]bool, len(indices))
+	selected := make(Hand, 0, len(indices))
+	for _, idx := range indices {
+		if idx < 0 || idx >= len(g.PlayerHand) {
+			return nil, nil, fmt.Errorf("there is no card %d", idx+1)
+		}
+		if chosen[idx] {
+			return nil, nil, fmt.Errorf("card %d is selected twice", idx+1)
+		}
+		chosen[idx] = true
+		card := g.PlayerHand[idx]
+		card.FaceDown = false
+		selected = append(selected, card)
+	}
+
+	held := make(Hand, 0, len(g.PlayerHand)-len(selected))
+	for idx, card := range g.PlayerHand {
+		if !chosen[idx] {
+			held = append(held, card)
+		}
+	}
+	return selected, held, nil
+}
+
+// ScoringContext returns the context a hand played right now would score in
+func (g *Game) ScoringContext(held Hand) ScoringContext {
+	return ScoringContext{
+		Held:        held,
+		Rand:        g.rng,
+		Levels:      g.HandLevels,
+		Jokers:      g.Jokers,
+		Discards:    g.Discards,
+		Boss:        g.ActiveBoss(),
+		PlayedHands: g.PlayedHands,
 	}
 }
 
+// PlayHand scores the selected cards against the blind and refills the
+// hand. Face down cards are revealed as they are played.
+func (g *Game) PlayHand(indices []int) (Hand, HandEvaluation, error) {
+	if g.Hands <= 0 {
+		return nil, HandEvaluation{}, fmt.Errorf("no hands left")
+	}
+	selected, held, err := g.splitHand(indices)
+	if err != nil {
+		return nil, HandEvaluation{}, err
+	}
+
+	evaluation := EvaluateHandWithContext(selected, g.ScoringContext(held))
+	g.Score += evaluation.TotalScore
+	g.Money += evaluation.Dollars
+	g.LastHandType = evaluation.Type
+	g.PlayedHands = append(g.PlayedHands, evaluation.Type)
+	for _, idx := range evaluation.Shattered {
+		g.FullDeck.Remove(selected[idx].ID)
+	}
+	g.Hands--
+	g.PlayerHand = held
+	for i := 0; i < g.ActiveBoss().HeldDiscards() && len(g.PlayerHand) > 0; i++ {
+		idx := g.rng.Intn(len(g.PlayerHand))
+		g.PlayerHand = append(g.PlayerHand[:idx], g.PlayerHand[idx+1:]...)
+	}
+	g.drawToHandSize(false)
+
+	return selected, evaluation, nil
+}
+
+// Discard throws away the selected cards and draws replacements. Each
+// discarded Purple Seal creates a Tarot card if there is room.
+func (g *Game) Discard(indices []int) (Hand, error) {
+	if g.Discards <= 0 {
+		return nil, fmt.Errorf("no discards left")
+	}
+	selected, held, err := g.splitHand(indices)
+	if err != nil {
+		return nil, err
+	}
+
+	g.Discards--
+	g.PlayerHand = held
+	g.drawToHandSize(false)
+	for _, card := range selected {
+		if card.Seal == PurpleSeal {
+			g.gainConsumable(TarotKinds[g.rng.Intn(len(TarotKinds))])
+		}
+	}
+
+	return selected, nil
+}
+
+func (g *Game) BlindCleared() bool {
+	return g.Score >= g.Target()
+}
+
+func (g *Game) BlindLost() bool {
+	return !g.BlindCleared() && (g.Hands <= 0 || len(g.PlayerHand) == 0)
+}
+
+// CashOut pays the rewards for a cleared blind and opens the shop. Clearing
+// the final boss blind wins the run instead.
+func (g *Game) CashOut() Payout {
+	payout := Payout{
+		Blind:    g.Blind.GetReward(g.Config.Stake),
+		Hands:    g.Hands,
+		Interest: interest(g.Money),
+	}
+	for idx, joker := range g.Jokers {
+		payout.Jokers += joker.GetRoundDollars()
+		if joker.Perishable && joker.RoundsLeft > 0 {
+			g.Jokers[idx].RoundsLeft--
+		}
+	}
+	for _, card := range g.PlayerHand {
+		if card.Enhancement == GoldCard {
+			payout.Held += 3
+		}
+		if card.Seal == BlueSeal {
+			g.gainConsumable(PlanetFor(g.LastHandType))
+		}
+	}
+	g.Money += payout.Total()
+
+	if g.Blind == BossBlind && g.Ante >= WinningAnte {
+		g.Over = true
+		g.Won = true
+		return payout
+	}
+	g.Shop = newShop(g.rng, g.Config.Stake)
+
+	return payout
+}
+
+// NextBlind leaves the shop and starts the following blind
+func (g *Game) NextBlind() {
+	if g.Blind == BossBlind {
+		g.Ante++
+		g.Blind = SmallBlind
+		g.Boss = g.randomBoss()
+	} else {
+		g.Blind++
+	}
+	g.Round++
+	g.StartBlind()
+}
+
+// Play runs the full screen interface when both stdin and stdout are
+// terminals, and the line prompts otherwise
 func (g *Game) Play() {
+	if isTerminal(os.Stdin.Fd()) && isTerminal(os.Stdout.Fd()) {
+		err := g.PlayTUI()
+		if err == nil {
+			return
+		}
+		fmt.Println("Falling back to prompts:", err)
+	}
+	g.PlayPrompt()
+}
+
+// PlayPrompt runs the game with line based prompts
+func (g *Game) PlayPrompt() {
 	fmt.Println("=== Welcome to Balatro CLI ===")
 	fmt.Println("Select up to 5 cards to form a poker hand and score points!")
+	fmt.Printf("Deck: %s (%s)\n", g.Config.Deck, g.Config.Deck.Description())
+	fmt.Printf("Stake: %s\n", g.Config.Stake)
+	fmt.Printf("Seed: %s\n", g.Seed)
 	fmt.Println()
 
 	reader := bufio.NewReader(os.Stdin)
 
-	for {
-		fmt.Printf("=== Round %d ===\n", g.Round)
-		fmt.Printf("Current Score: %d\n", g.Score)
-		fmt.Println()
-
-		// Deal 8 cards for the player to choose from
-		availableCards := g.Deck.Draw(8)
-		if len(availableCards) == 0 {
-			fmt.Println("No more cards in the deck! Game Over!")
-			break
+	for !g.Over {
+		g.autosave()
+		if g.Shop != nil {
+			if !g.shopPhase(reader) {
+				break
+			}
+			continue
 		}
 
-		fmt.Println("Available cards:")
-		for i, card := range availableCards {
-			fmt.Printf("%d: %s ", i+1, card)
+		fmt.Printf("=== Round %d: Ante %d, %s ===\n", g.Round, g.Ante, g.Blind)
+		fmt.Printf("Score: %d / %d\n", g.Score, g.Target())
+		fmt.Printf("Hands: %d  Discards: %d  Money: $%d\n", g.Hands, g.Discards, g.Money)
+		if boss := g.ActiveBoss(); boss != NoBoss {
+			fmt.Printf("Boss: %s - %s\n", boss, boss.Description())
+		} else {
+			fmt.Printf("Upcoming boss: %s - %s\n", g.Boss, g.Boss.Description())
+		}
+		if len(g.Jokers) > 0 {
+			fmt.Printf("Jokers: %s\n", jokerList(g.Jokers))
 		}
+		printConsumables(g.Consumables)
 		fmt.Println()
+
+		g.printHand()
 		fmt.Println()
 
-		// Let player select up to 5 cards
-		selectedCards := g.selectCards(availableCards, reader)
-		
-		if len(selectedCards) == 0 {
+		action, ok := g.readAction(reader)
+		if !ok {
 			fmt.Println("No cards selected. Ending game.")
 			break
 		}
+		indices := action.Indices
+
+		if action.Discard {
+			if g.Discards > 0 {
+				fmt.Print(g.DiscardOdds(indices))
+				fmt.Print("Confirm discard? (y/n) ")
+				if input, _ := readInput(reader); input != "y" && input != "yes" {
+					fmt.Println()
+					continue
+				}
+			}
+			discarded, err := g.Discard(indices)
+			if err != nil {
+				fmt.Println(err)
+			} else {
+				fmt.Printf("Discarded %s\n\n", discarded)
+			}
+			continue
+		}
 
-		// Evaluate and score the hand
-		evaluation := EvaluateHand(selectedCards)
-		g.Score += evaluation.TotalScore
+		selectedCards, evaluation, err := g.PlayHand(indices)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
 
 		fmt.Println()
-		fmt.Printf("Selected hand: %s\n", Hand(selectedCards))
-		fmt.Printf("Hand type: %s\n", evaluation.Type)
+		fmt.Printf("Selected hand: %s\n", selectedCards)
+		fmt.Printf("Hand type: %s (level %d)\n", evaluation.Type, g.HandLevels.Level(evaluation.Type))
+		if evaluation.NotAllowed {
+			fmt.Printf("%s does not allow this hand, it scores nothing!\n", g.ActiveBoss())
+		}
 		fmt.Printf("Card value total: %d\n", evaluation.CardValue)
 		fmt.Printf("Multiplier: %dx\n", evaluation.Multiplier)
-		fmt.Printf("Round score: %d\n", evaluation.TotalScore)
-		fmt.Printf("Total score: %d\n", g.Score)
+		if evaluation.Mult != float64(evaluation.Multiplier) {
+			fmt.Printf("Multiplier after card effects: %.1fx\n", evaluation.Mult)
+		}
+		for _, idx := range evaluation.Shattered {
+			fmt.Printf("%s shattered!\n", selectedCards[idx])
+		}
+		if evaluation.Dollars > 0 {
+			fmt.Printf("Earned $%d\n", evaluation.Dollars)
+		}
+		fmt.Printf("Hand score: %d\n", evaluation.TotalScore)
+		fmt.Printf("Blind score: %d / %d\n", g.Score, g.Target())
 		fmt.Println()
 
-		// Ask if player wants to continue
-		fmt.Print("Continue to next round? (y/n): ")
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(strings.ToLower(input))
-		
-		if input != "y" && input != "yes" {
-			break
+		if g.BlindCleared() {
+			payout := g.CashOut()
+			fmt.Printf("%s cleared!\n", g.Blind)
+			fmt.Printf("Blind reward: $%d, remaining hands: $%d, interest: $%d", payout.Blind, payout.Hands, payout.Interest)
+			if payout.Jokers > 0 {
+				fmt.Printf(", jokers: $%d", payout.Jokers)
+			}
+			if payout.Held > 0 {
+				fmt.Printf(", gold cards: $%d", payout.Held)
+			}
+			fmt.Printf("\nMoney: $%d\n\n", g.Money)
+		} else if g.BlindLost() {
+			fmt.Println("Out of hands! Game Over!")
+			g.Over = true
 		}
+	}
 
-		g.Round++
-		fmt.Println()
+	g.finish()
+}
+
+// finish saves the run, or removes the save of a finished run, and prints
+// how far the run got
+func (g *Game) finish() {
+	g.autosave()
+	if g.Won {
+		fmt.Println("You beat the final boss blind. You win!")
 	}
+	fmt.Printf("\nGame finished! Reached ante %d after %d rounds with $%d\n", g.Ante, g.Round, g.Money)
+}
 
-	fmt.Printf("\nGame finished! Final score: %d\n", g.Score)
+// SortHand orders the cards in hand by rank, highest first, or by suit and
+// then rank. Face down cards stay unsorted at the end so their order gives
+// nothing away.
+func (g *Game) SortHand(bySuit bool) {
+	sort.SliceStable(g.PlayerHand, func(i, j int) bool {
+		a, b := g.PlayerHand[i], g.PlayerHand[j]
+		if a.FaceDown || b.FaceDown {
+			return !a.FaceDown && b.FaceDown
+		}
+		if bySuit && a.Suit != b.Suit {
+			return a.Suit < b.Suit
+		}
+		return a.Rank > b.Rank
+	})
 }
 
-func (g *Game) selectCards(availableCards []Card, reader *bufio.Reader) []Card {
-	selectedCards := make([]Card, 0, 5)
-	
-	for len(selectedCards) < 5 {
-		if len(selectedCards) > 0 {
-			fmt.Printf("Selected cards (%d/5): %s\n", len(selectedCards), Hand(selectedCards))
+// printHand lists the cards in hand, marking the ones the boss debuffs
+func (g *Game) printHand() {
+	fmt.Println("Available cards:")
+	for i, card := range g.PlayerHand {
+		if !card.FaceDown && g.ActiveBoss().Debuffs(card) {
+			fmt.Printf("%d: %s(debuffed) ", i+1, card)
+		} else {
+			fmt.Printf("%d: %s ", i+1, card)
 		}
-		
-		fmt.Printf("Select a card (1-%d) or 'done' to finish selection: ", len(availableCards))
-		input, _ := reader.ReadString('\n')
-		input = strings.TrimSpace(strings.ToLower(input))
-		
-		if input == "done" || input == "d" {
-			break
+	}
+	fmt.Println()
+}
+
+// readAction reads commands until the player plays or discards. It returns
+// false if the input runs out or the player finishes with nothing selected.
+func (g *Game) readAction(reader *bufio.Reader) (Action, bool) {
+	selected := make([]int, 0, MaxSelection)
+
+	for {
+		if len(selected) > 0 {
+			fmt.Printf("Selected cards (%d/%d): %s\n", len(selected), MaxSelection, g.selectedHand(selected))
 		}
-		
-		cardIndex, err := strconv.Atoi(input)
-		if err != nil || cardIndex < 1 || cardIndex > len(availableCards) {
-			fmt.Println("Invalid selection. Please enter a number between 1 and", len(availableCards))
+		fmt.Printf("Select cards (1-%d), then play or discard them ('help' lists commands): ", len(g.PlayerHand))
+		input, ok := readInput(reader)
+		if !ok {
+			return Action{}, false
+		}
+
+		command, err := ParseCommand(input, g.PlayerHand)
+		if err != nil {
+			fmt.Println(err)
 			continue
 		}
-		
-		selectedCard := availableCards[cardIndex-1]
-		
-		// Check if card is already selected
-		alreadySelected := false
-		for _, card := range selectedCards {
-			if card.Suit == selectedCard.Suit && card.Rank == selectedCard.Rank {
-				alreadySelected = true
-				break
+
+		switch command.Kind {
+		case SelectCommand:
+			for _, idx := range command.Cards {
+				if containsIndex(selected, idx) {
+					fmt.Printf("%s is already selected\n", g.PlayerHand[idx])
+					continue
+				}
+				if len(selected) == MaxSelection {
+					fmt.Printf("Hand is full (%d cards)\n", MaxSelection)
+					break
+				}
+				selected = append(selected, idx)
+				fmt.Printf("Added %s to your hand\n", g.PlayerHand[idx])
+			}
+		case PlayCommand, DiscardCommand:
+			cards := command.Cards
+			if len(cards) == 0 {
+				cards = selected
+			}
+			if len(cards) == 0 {
+				fmt.Println("Select some cards first")
+				continue
+			}
+			return Action{Discard: command.Kind == DiscardCommand, Indices: cards}, true
+		case SortCommand:
+			// Keep the selection on the same cards after they move
+			ids := make(map[int]bool, len(selected))
+			for _, idx := range selected {
+				ids[g.PlayerHand[idx].ID] = true
+			}
+			g.SortHand(command.BySuit)
+			selected = selected[:0]
+			for idx, card := range g.PlayerHand {
+				if ids[card.ID] {
+					selected = append(selected, idx)
+				}
+			}
+			g.printHand()
+		case UseCommand:
+			targets := command.Cards
+			if len(targets) == 0 {
+				targets = selected
+			}
+			message, err := g.UseConsumable(command.Consumable, targets)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			fmt.Println(message)
+			fmt.Println()
+			g.autosave()
+			// The hand may have changed, so start the selection over
+			selected = selected[:0]
+			g.printHand()
+			printConsumables(g.Consumables)
+		case SuggestCommand:
+			printSuggestions(g.SuggestHands(suggestions))
+		case DeckCommand:
+			fmt.Print(g.DeckView())
+		case HelpCommand:
+			fmt.Println(commandHelp)
+		case DoneCommand:
+			if len(selected) == 0 {
+				return Action{}, false
+			}
+			if g.Discards > 0 {
+				fmt.Print("(p)lay or (d)iscard these cards? ")
+				input, _ := readInput(reader)
+				if input == "d" || input == "discard" {
+					return Action{Discard: true, Indices: selected}, true
+				}
 			}
+			return Action{Indices: selected}, true
 		}
-		
-		if alreadySelected {
-			fmt.Println("Card already selected!")
+		fmt.Println()
+	}
+}
+
+func containsIndex(indices []int, target int) bool {
+	for _, idx := range indices {
+		if idx == target {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *Game) selectedHand(indices []int) Hand {
+	hand := make(Hand, 0, len(indices))
+	for _, idx := range indices {
+		hand = append(hand, g.PlayerHand[idx])
+	}
+	return hand
+}
+
+// shopPhase runs the shop prompt until the player moves on. It returns false
+// if the player quits the run.
+func (g *Game) shopPhase(reader *bufio.Reader) bool {
+	for {
+		g.autosave()
+		shop := g.Shop
+		fmt.Printf("=== Shop === Money: $%d\n", g.Money)
+
+		if len(shop.Pack) > 0 {
+			fmt.Println("Pack contents:")
+			for i, item := range shop.Pack {
+				fmt.Printf("%d: %s\n", i+1, item)
+			}
+			fmt.Print("Pick a card (number) or 'skip' (k): ")
+			input, ok := readInput(reader)
+			if !ok {
+				return false
+			}
+			choice := -1
+			if input != "skip" && input != "k" {
+				n, err := strconv.Atoi(input)
+				if err != nil {
+					fmt.Println("Please enter a number or 'skip'")
+					continue
+				}
+				choice = n - 1
+			}
+			message, err := g.ChoosePackItem(choice)
+			if err != nil {
+				fmt.Println(err)
+			} else {
+				fmt.Println(message)
+			}
+			fmt.Println()
 			continue
 		}
-		
-		selectedCards = append(selectedCards, selectedCard)
-		fmt.Printf("Added %s to your hand\n", selectedCard)
-		
-		if len(selectedCards) == 5 {
-			fmt.Println("Hand is full (5 cards)")
-			break
+
+		for i, item := range shop.Items {
+			fmt.Printf("%d: %s - $%d\n", i+1, item, item.Price)
+		}
+		if len(g.Jokers) > 0 {
+			fmt.Println("Your jokers:")
+			for i, joker := range g.Jokers {
+				fmt.Printf("%d: %s (%s) - sells for $%d\n", i+1, joker, joker.Kind.Description(), joker.GetSellValue())
+			}
+		}
+		printConsumables(g.Consumables)
+		fmt.Printf("Commands: buy <n>, sell <n>, use <n>, reroll ($%d), levels, next, quit\n", shop.RerollCost)
+		fmt.Print("> ")
+
+		input, ok := readInput(reader)
+		if !ok {
+			return false
+		}
+		fields := strings.Fields(input)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "buy", "b", "sell", "s":
+			if len(fields) < 2 {
+				fmt.Printf("Usage: %s <n>\n", fields[0])
+				break
+			}
+			n, err := strconv.Atoi(fields[1])
+			if err != nil {
+				fmt.Println("Please enter a number")
+				break
+			}
+			if fields[0] == "buy" || fields[0] == "b" {
+				message, err := g.Buy(n - 1)
+				if err != nil {
+					fmt.Println(err)
+				} else {
+					fmt.Println(message)
+				}
+			} else {
+				value, err := g.SellJoker(n - 1)
+				if err != nil {
+					fmt.Println(err)
+				} else {
+					fmt.Printf("Sold for $%d\n", value)
+				}
+			}
+		case "use", "u":
+			if len(fields) < 2 {
+				fmt.Println("Usage: use <n>")
+				break
+			}
+			n, err := strconv.Atoi(fields[1])
+			if err != nil {
+				fmt.Println("Please enter a number")
+				break
+			}
+			message, err := g.UseConsumable(n-1, nil)
+			if err != nil {
+				fmt.Println(err)
+			} else {
+				fmt.Println(message)
+			}
+		case "reroll", "r":
+			if err := g.Reroll(); err != nil {
+				fmt.Println(err)
+			}
+		case "levels", "l":
+			printHandLevels(g.HandLevels)
+		case "next", "n":
+			g.NextBlind()
+			fmt.Println()
+			return true
+		case "quit", "q":
+			return false
+		default:
+			fmt.Println("Unknown command:", fields[0])
 		}
 		fmt.Println()
 	}
-	
-	return selectedCards
-}
\ No newline at end of file
+}
+
+// readInput reads a trimmed, lower-cased line. It returns false once the
+// input is exhausted so the prompts don't spin forever on a closed stdin.
+func readInput(reader *bufio.Reader) (string, bool) {
+	input, err := reader.ReadString('\n')
+	input = strings.TrimSpace(strings.ToLower(input))
+	if err != nil && input == "" {
+		return "", false
+	}
+	return input, true
+}
+
+// printSuggestions lists hands with the card numbers to select for each
+func printSuggestions(options []HandOption) {
+	fmt.Println("Best hands:")
+	for i, option := range options {
+		numbers := make([]string, 0, len(option.Indices))
+		for _, idx := range option.Indices {
+			numbers = append(numbers, strconv.Itoa(idx+1))
+		}
+		fmt.Printf("  %d. %s (select %s)\n", i+1, option, strings.Join(numbers, " "))
+	}
+	fmt.Println()
+}
+
+func jokerList(jokers []Joker) string {
+	names := make([]string, 0, len(jokers))
+	for _, joker := range jokers {
+		names = append(names, joker.String())
+	}
+	return strings.Join(names, ", ")
+}
+
+func printConsumables(consumables []Consumable) {
+	if len(consumables) == 0 {
+		return
+	}
+	fmt.Printf("Consumables (%d/%d):\n", len(consumables), MaxConsumables)
+	for i, consumable := range consumables {
+		fmt.Printf("  %d: %s - %s\n", i+1, consumable, consumable.Kind.Description())
+	}
+}
+
+func printHandLevels(levels HandLevels) {
+	types := make([]HandType, 0, len(levels))
+	for handType := range levels {
+		types = append(types, handType)
+	}
+	if len(types) == 0 {
+		fmt.Println("Every hand is level 1")
+		return
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+	for _, handType := range types {
+		fmt.Printf("%s: level %d\n", handType, levels.Level(handType))
+	}
+}
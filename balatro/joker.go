@@ -0,0 +1,329 @@
+package balatro
+
+import (
+	"fmt"
+	"strings"
+)
+
+type JokerKind int
+
+const (
+	PlainJoker JokerKind = iota
+	GreedyJoker
+	LustyJoker
+	WrathfulJoker
+	GluttonousJoker
+	JollyJoker
+	ZanyJoker
+	MadJoker
+	CrazyJoker
+	DrollJoker
+	SlyJoker
+	WilyJoker
+	HalfJoker
+	Banner
+	ScaryFace
+	AbstractJoker
+	Cavendish
+	GoldenJoker
+	FourFingers
+	Shortcut
+)
+
+// JokerKinds lists every joker the shop can offer
+var JokerKinds = []JokerKind{
+	PlainJoker, GreedyJoker, LustyJoker, WrathfulJoker, GluttonousJoker,
+	JollyJoker, ZanyJoker, MadJoker, CrazyJoker, DrollJoker, SlyJoker, WilyJoker,
+	HalfJoker, Banner, ScaryFace, AbstractJoker, Cavendish, GoldenJoker, FourFingers, Shortcut,
+}
+
+func (k JokerKind) String() string {
+	switch k {
+	case PlainJoker:
+		return "Joker"
+	case GreedyJoker:
+		return "Greedy Joker"
+	case LustyJoker:
+		return "Lusty Joker"
+	case WrathfulJoker:
+		return "Wrathful Joker"
+	case GluttonousJoker:
+		return "Gluttonous Joker"
+	case JollyJoker:
+		return "Jolly Joker"
+	case ZanyJoker:
+		return "Zany Joker"
+	case MadJoker:
+		return "Mad Joker"
+	case CrazyJoker:
+		return "Crazy Joker"
+	case DrollJoker:
+		return "Droll Joker"
+	case SlyJoker:
+		return "Sly Joker"
+	case WilyJoker:
+		return "Wily Joker"
+	case HalfJoker:
+		return "Half Joker"
+	case Banner:
+		return "Banner"
+	case ScaryFace:
+		return "Scary Face"
+	case AbstractJoker:
+		return "Abstract Joker"
+	case Cavendish:
+		return "Cavendish"
+	case GoldenJoker:
+		return "Golden Joker"
+	case FourFingers:
+		return "Four Fingers"
+	case Shortcut:
+		return "Shortcut"
+	default:
+		return "Unknown Joker"
+	}
+}
+
+// Description explains the joker's effect for the shop and joker list
+func (k JokerKind) Description() string {
+	switch k {
+	case PlainJoker:
+		return "+4 Mult"
+	case GreedyJoker:
+		return "+3 Mult for each scored ♦"
+	case LustyJoker:
+		return "+3 Mult for each scored ♥"
+	case WrathfulJoker:
+		return "+3 Mult for each scored ♠"
+	case GluttonousJoker:
+		return "+3 Mult for each scored ♣"
+	case JollyJoker:
+		return "+8 Mult if the hand contains a Pair"
+	case ZanyJoker:
+		return "+12 Mult if the hand contains a Three of a Kind"
+	case MadJoker:
+		return "+10 Mult if the hand contains a Two Pair"
+	case CrazyJoker:
+		return "+12 Mult if the hand contains a Straight"
+	case DrollJoker:
+		return "+10 Mult if the hand contains a Flush"
+	case SlyJoker:
+		return "+50 Chips if the hand contains a Pair"
+	case WilyJoker:
+		return "+100 Chips if the hand contains a Three of a Kind"
+	case HalfJoker:
+		return "+20 Mult if 3 or fewer cards are played"
+	case Banner:
+		return "+30 Chips for each remaining discard"
+	case ScaryFace:
+		return "+30 Chips for each scored face card"
+	case AbstractJoker:
+		return "+3 Mult for each joker owned"
+	case Cavendish:
+		return "x3 Mult"
+	case GoldenJoker:
+		return "Earn $4 at the end of each round"
+	case FourFingers:
+		return "Flushes and Straights can be made with 4 cards"
+	case Shortcut:
+		return "Straights can skip a single rank"
+	default:
+		return ""
+	}
+}
+
+// GetCost returns the shop price of a joker without an edition
+func (k JokerKind) GetCost() int {
+	switch k {
+	case PlainJoker:
+		return 2
+	case GreedyJoker, LustyJoker, WrathfulJoker, GluttonousJoker, Banner:
+		return 5
+	case JollyJoker, SlyJoker, HalfJoker:
+		return 3
+	case ZanyJoker, MadJoker, CrazyJoker, DrollJoker, WilyJoker:
+		return 4
+	case ScaryFace, GoldenJoker:
+		return 6
+	case AbstractJoker:
+		return 4
+	case Cavendish:
+		return 4
+	case FourFingers, Shortcut:
+		return 7
+	default:
+		return 5
+	}
+}
+
+// PerishableRounds is how many rounds a Perishable joker works for
+const PerishableRounds = 5
+
+type Joker struct {
+	Kind    JokerKind
+	Edition Edition
+	// Eternal jokers can't be sold
+	Eternal bool
+	// Perishable jokers are debuffed once RoundsLeft runs out
+	Perishable bool
+	RoundsLeft int
+}
+
+func (j Joker) String() string {
+	var modifiers []string
+	if j.Edition != NoEdition {
+		modifiers = append(modifiers, j.Edition.String())
+	}
+	if j.Eternal {
+		modifiers = append(modifiers, "Eternal")
+	}
+	if j.Perishable {
+		if j.Debuffed() {
+			modifiers = append(modifiers, "Perished")
+		} else {
+			modifiers = append(modifiers, fmt.Sprintf("Perishable %d", j.RoundsLeft))
+		}
+	}
+	if len(modifiers) == 0 {
+		return j.Kind.String()
+	}
+	return fmt.Sprintf("%s[%s]", j.Kind, strings.Join(modifiers, ","))
+}
+
+// Debuffed reports whether the joker has stopped working
+func (j Joker) Debuffed() bool {
+	return j.Perishable && j.RoundsLeft <= 0
+}
+
+// GetCost returns the shop price, including the surcharge for an edition
+func (j Joker) GetCost() int {
+	cost := j.Kind.GetCost()
+	switch j.Edition {
+	case Foil:
+		cost += 2
+	case Holographic:
+		cost += 3
+	case Polychrome:
+		cost += 5
+	}
+	return cost
+}
+
+// GetSellValue returns what the shop pays for the joker: half its cost, at least $1
+func (j Joker) GetSellValue() int {
+	value := j.GetCost() / 2
+	if value < 1 {
+		return 1
+	}
+	return value
+}
+
+// suitJokers maps the "+3 Mult per suit" jokers to the suit they count
+var suitJokers = map[JokerKind]Suit{
+	GreedyJoker:     Diamonds,
+	LustyJoker:      Hearts,
+	WrathfulJoker:   Spades,
+	GluttonousJoker: Clubs,
+}
+
+// apply adds the joker's effect to an evaluation once every card has scored
+func (j Joker) apply(e *HandEvaluation, hand Hand, ctx ScoringContext) {
+	if j.Debuffed() {
+		return
+	}
+
+	switch j.Kind {
+	case PlainJoker:
+		e.Mult += 4
+	case GreedyJoker, LustyJoker, WrathfulJoker, GluttonousJoker:
+		for _, card := range hand {
+			if card.IsSuit(suitJokers[j.Kind]) && !ctx.Boss.Debuffs(card) {
+				e.Mult += 3
+			}
+		}
+	case JollyJoker:
+		if containsHand(hand, Pair, ctx.rules()) {
+			e.Mult += 8
+		}
+	case ZanyJoker:
+		if containsHand(hand, ThreeOfAKind, ctx.rules()) {
+			e.Mult += 12
+		}
+	case MadJoker:
+		if containsHand(hand, TwoPair, ctx.rules()) {
+			e.Mult += 10
+		}
+	case CrazyJoker:
+		if containsHand(hand, Straight, ctx.rules()) {
+			e.Mult += 12
+		}
+	case DrollJoker:
+		if containsHand(hand, Flush, ctx.rules()) {
+			e.Mult += 10
+		}
+	case SlyJoker:
+		if containsHand(hand, Pair, ctx.rules()) {
+			e.CardValue += 50
+		}
+	case WilyJoker:
+		if containsHand(hand, ThreeOfAKind, ctx.rules()) {
+			e.CardValue += 100
+		}
+	case HalfJoker:
+		if len(hand) <= 3 {
+			e.Mult += 20
+		}
+	case Banner:
+		e.CardValue += 30 * ctx.Discards
+	case ScaryFace:
+		for _, card := range hand {
+			if card.IsFace() && !ctx.Boss.Debuffs(card) {
+				e.CardValue += 30
+			}
+		}
+	case AbstractJoker:
+		e.Mult += float64(3 * len(ctx.Jokers))
+	case Cavendish:
+		e.Mult *= 3
+	}
+
+	switch j.Edition {
+	case Foil:
+		e.CardValue += 50
+	case Holographic:
+		e.Mult += 10
+	case Polychrome:
+		e.Mult *= 1.5
+	}
+}
+
+// GetRoundDollars returns the money the joker pays out when a blind is cleared
+func (j Joker) GetRoundDollars() int {
+	if j.Kind == GoldenJoker && !j.Debuffed() {
+		return 4
+	}
+	return 0
+}
+
+// containsHand reports whether the played cards include the given hand type,
+// e.g. a Full House contains both a Pair and a Three of a Kind
+func containsHand(hand Hand, target HandType, rules HandRules) bool {
+	shape := analyzeHand(hand, rules)
+
+	switch target {
+	case Pair:
+		return shape.top >= 2
+	case TwoPair:
+		return shape.pairs >= 2
+	case ThreeOfAKind:
+		return shape.top >= 3
+	case FourOfAKind:
+		return shape.top >= 4
+	case Straight:
+		return shape.straight
+	case Flush:
+		return shape.flush
+	default:
+		return shape.handType() == target
+	}
+}
@@ -0,0 +1,195 @@
+package balatro
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxExactDraws is the most draw combinations enumerated exactly before
+	// falling back to sampling
+	maxExactDraws = 20000
+	// oddsSamples is how many draws are sampled when there are too many to enumerate
+	oddsSamples = 5000
+)
+
+// DrawOdds is the chance of each hand type being the best one available
+// after discarding and drawing back up
+type DrawOdds struct {
+	Probabilities map[HandType]float64
+	// Draws is how many cards are drawn
+	Draws int
+	// Exact is false when the odds were estimated from Samples random draws
+	Exact   bool
+	Samples int
+}
+
+// AtLeast returns the chance of ending up with the hand type or a better one
+func (o DrawOdds) AtLeast(handType HandType) float64 {
+	total := 0.0
+	for ht, p := range o.Probabilities {
+		if ht >= handType {
+			total += p
+		}
+	}
+	return total
+}
+
+func (o DrawOdds) String() string {
+	types := make([]HandType, 0, len(o.Probabilities))
+	for handType := range o.Probabilities {
+		types = append(types, handType)
+	}
+	sort.Slice(types, func(a, b int) bool { return types[a] > types[b] })
+
+	method := "exact"
+	if !o.Exact {
+		method = fmt.Sprintf("estimated from %d draws", o.Samples)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "Best hand after drawing %d (%s):\n", o.Draws, method)
+	for _, handType := range types {
+		fmt.Fprintf(&b, "  %-16s %5.1f%%\n", handType, o.Probabilities[handType]*100)
+	}
+	return b.String()
+}
+
+// DiscardOdds works out the best hand type available after discarding the
+// cards at the given indexes and drawing back up to HandSize from remaining.
+// Face down cards are unknown to the player, so they are treated as any of
+// the cards not yet seen. Small draws are enumerated exactly; larger ones are
+// sampled with rng, or with a fixed seed if rng is nil.
+func DiscardOdds(hand Hand, discard []int, remaining []Card, rules HandRules, rng *rand.Rand) DrawOdds {
+	dropped := make(map[int]bool, len(discard))
+	for _, idx := range discard {
+		dropped[idx] = true
+	}
+	next := make(Hand, 0, len(hand)+HandSize)
+	unseen := append([]Card{}, remaining...)
+	hidden := 0
+	for idx, card := range hand {
+		switch {
+		case card.FaceDown:
+			unseen = append(unseen, card)
+			if !dropped[idx] {
+				hidden++
+			}
+		case !dropped[idx]:
+			next = append(next, card)
+		}
+	}
+	kept := len(next)
+	draws := HandSize - kept - hidden
+	if draws < 0 {
+		draws = 0
+	}
+	if draws > len(remaining) {
+		draws = len(remaining)
+	}
+	// unknown is every card in the next hand the player can't see yet
+	unknown := hidden + draws
+	next = next[:kept+unknown]
+
+	odds := DrawOdds{Probabilities: map[HandType]float64{}, Draws: draws}
+	counts := map[HandType]int{}
+	scratch := make(Hand, 0, MaxSelection)
+
+	if combinations(len(unseen), unknown) <= maxExactDraws {
+		odds.Exact = true
+		total := 0
+		forEachIndexSet(len(unseen), unknown, func(picked []int) {
+			for i, idx := range picked {
+				next[kept+i] = unseen[idx]
+			}
+			counts[bestHandType(next, rules, scratch)]++
+			total++
+		})
+		odds.Samples = total
+	} else {
+		if rng == nil {
+			rng = rand.New(rand.NewSource(1))
+		}
+		pool := make([]Card, len(unseen))
+		copy(pool, unseen)
+		for i := 0; i < oddsSamples; i++ {
+			for j := 0; j < unknown; j++ {
+				k := j + rng.Intn(len(pool)-j)
+				pool[j], pool[k] = pool[k], pool[j]
+				next[kept+j] = pool[j]
+			}
+			counts[bestHandType(next, rules, scratch)]++
+		}
+		odds.Samples = oddsSamples
+	}
+
+	for handType, count := range counts {
+		odds.Probabilities[handType] = float64(count) / float64(odds.Samples)
+	}
+	return odds
+}
+
+// bestHandType returns the best hand type that can be played from the hand.
+// Adding cards never makes a hand worse, so only the largest subsets are checked.
+func bestHandType(hand Hand, rules HandRules, scratch Hand) HandType {
+	size := len(hand)
+	if size > MaxSelection {
+		size = MaxSelection
+	}
+	best := HighCard
+	forEachIndexSet(len(hand), size, func(picked []int) {
+		scratch = scratch[:0]
+		for _, idx := range picked {
+			scratch = append(scratch, hand[idx])
+		}
+		if handType := determineHandTypeWithRules(scratch, rules); handType > best {
+			best = handType
+		}
+	})
+	return best
+}
+
+// forEachIndexSet calls visit with every k sized set of indexes below n,
+// in increasing order. picked is reused between calls.
+func forEachIndexSet(n int, k int, visit func(picked []int)) {
+	if k > n || k < 0 {
+		return
+	}
+	picked := make([]int, k)
+	for i := range picked {
+		picked[i] = i
+	}
+	for {
+		visit(picked)
+		i := k - 1
+		for i >= 0 && picked[i] == n-k+i {
+			i--
+		}
+		if i < 0 {
+			return
+		}
+		picked[i]++
+		for j := i + 1; j < k; j++ {
+			picked[j] = picked[j-1] + 1
+		}
+	}
+}
+
+// combinations returns n choose k
+func combinations(n int, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+	return result
+}
+
+// DiscardOdds works out the odds of discarding the selected cards, drawing
+// from what is left of the draw pile
+func (g *Game) DiscardOdds(indices []int) DrawOdds {
+	return DiscardOdds(g.PlayerHand, indices, g.Deck.Cards, g.ScoringContext(nil).rules(), nil)
+}
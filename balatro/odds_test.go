@@ -0,0 +1,121 @@
+package balatro
+
+import (
+	"math"
+	"testing"
+)
+
+func totalProbability(odds DrawOdds) float64 {
+	total := 0.0
+	for _, p := range odds.Probabilities {
+		total += p
+	}
+	return total
+}
+
+func TestDiscardOddsExact(t *testing.T) {
+	hand := Hand{
+		{Suit: Hearts, Rank: Two},
+		{Suit: Hearts, Rank: Six},
+		{Suit: Hearts, Rank: Nine},
+		{Suit: Hearts, Rank: King},
+		{Suit: Spades, Rank: Three},
+		{Suit: Clubs, Rank: Jack},
+		{Suit: Spades, Rank: Queen},
+		{Suit: Diamonds, Rank: Five},
+	}
+	remaining := []Card{
+		{Suit: Hearts, Rank: Four},
+		{Suit: Hearts, Rank: Jack},
+		{Suit: Clubs, Rank: Two},
+		{Suit: Diamonds, Rank: Eight},
+	}
+
+	odds := DiscardOdds(hand, []int{4}, remaining, HandRules{}, nil)
+	if !odds.Exact || odds.Draws != 1 || odds.Samples != 4 {
+		t.Fatalf("Expected 4 exact draws of 1 card, got %+v", odds)
+	}
+	expected := map[HandType]float64{Flush: 0.5, Pair: 0.25, HighCard: 0.25}
+	for handType, p := range expected {
+		if got := odds.Probabilities[handType]; math.Abs(got-p) > 1e-9 {
+			t.Errorf("Expected %s %.2f, got %.2f", handType, p, got)
+		}
+	}
+	if got := odds.AtLeast(Pair); math.Abs(got-0.75) > 1e-9 {
+		t.Errorf("Expected a Pair or better 75%% of the time, got %.2f", got)
+	}
+
+	// Four Fingers already makes the four hearts a flush
+	odds = DiscardOdds(hand, []int{4}, remaining, HandRules{FourFingers: true}, nil)
+	if got := odds.AtLeast(Flush); math.Abs(got-1) > 1e-9 {
+		t.Errorf("Expected a flush every time with Four Fingers, got %.2f", got)
+	}
+}
+
+func TestDiscardOddsFaceDown(t *testing.T) {
+	hand := Hand{
+		{Suit: Hearts, Rank: Two},
+		{Suit: Hearts, Rank: Six},
+		{Suit: Hearts, Rank: Nine},
+		{Suit: Hearts, Rank: King},
+		{Suit: Spades, Rank: Three},
+		{Suit: Clubs, Rank: Jack},
+		{Suit: Spades, Rank: Queen},
+		{Suit: Diamonds, Rank: Five, FaceDown: true},
+	}
+	remaining := []Card{
+		{Suit: Hearts, Rank: Four},
+		{Suit: Clubs, Rank: Two},
+	}
+
+	// the face down card could be any unseen card, so two of the three
+	// are drawn alongside it
+	odds := DiscardOdds(hand, []int{4}, remaining, HandRules{}, nil)
+	if !odds.Exact || odds.Draws != 1 || odds.Samples != 3 {
+		t.Fatalf("Expected 3 exact draws of 1 card, got %+v", odds)
+	}
+	expected := map[HandType]float64{Flush: 2.0 / 3, Pair: 1.0 / 3}
+	for handType, p := range expected {
+		if got := odds.Probabilities[handType]; math.Abs(got-p) > 1e-9 {
+			t.Errorf("Expected %s %.2f, got %.2f", handType, p, got)
+		}
+	}
+}
+
+func TestDiscardOddsSampled(t *testing.T) {
+	deck := NewDeck()
+	hand := Hand(deck.Draw(HandSize))
+
+	odds := DiscardOdds(hand, []int{0, 1, 2, 3, 4}, deck.Cards, HandRules{}, nil)
+	if odds.Exact || odds.Samples != oddsSamples || odds.Draws != 5 {
+		t.Errorf("Expected %d sampled draws of 5 cards, got %+v", oddsSamples, odds)
+	}
+	if total := totalProbability(odds); math.Abs(total-1) > 1e-9 {
+		t.Errorf("Expected the odds to add up to 1, got %f", total)
+	}
+
+	// Drawing more cards than are left only draws what there is
+	odds = DiscardOdds(hand, []int{0, 1}, deck.Cards[:1], HandRules{}, nil)
+	if odds.Draws != 1 || !odds.Exact {
+		t.Errorf("Expected a single exact draw, got %+v", odds)
+	}
+}
+
+func TestCombinations(t *testing.T) {
+	tests := []struct{ n, k, expected int }{
+		{52, 5, 2598960}, {44, 3, 13244}, {5, 0, 1}, {3, 4, 0},
+	}
+	for _, tt := range tests {
+		if got := combinations(tt.n, tt.k); got != tt.expected {
+			t.Errorf("combinations(%d, %d) = %d, want %d", tt.n, tt.k, got, tt.expected)
+		}
+		if tt.n > 50 {
+			continue
+		}
+		count := 0
+		forEachIndexSet(tt.n, tt.k, func([]int) { count++ })
+		if count != tt.expected {
+			t.Errorf("Expected %d index sets of %d from %d, got %d", tt.expected, tt.k, tt.n, count)
+		}
+	}
+}
@@ -1,7 +1,7 @@
 package balatro
 
 import (
-	"sort"
+	"math/rand"
 )
 
 type HandType int
@@ -17,6 +17,11 @@ const (
 	FourOfAKind
 	StraightFlush
 	RoyalFlush
+	// The secret hands need duplicate cards, so they only show up once the
+	// deck has been modified
+	FiveOfAKind
+	FlushHouse
+	FlushFive
 )
 
 func (ht HandType) String() string {
@@ -41,6 +46,12 @@ func (ht HandType) String() string {
 		return "Straight Flush"
 	case RoyalFlush:
 		return "Royal Flush"
+	case FiveOfAKind:
+		return "Five of a Kind"
+	case FlushHouse:
+		return "Flush House"
+	case FlushFive:
+		return "Flush Five"
 	default:
 		return "Unknown"
 	}
@@ -69,135 +80,369 @@ func (ht HandType) GetBaseMultiplier() int {
 		return 15
 	case RoyalFlush:
 		return 25
+	case FiveOfAKind:
+		return 30
+	case FlushHouse:
+		return 35
+	case FlushFive:
+		return 40
 	default:
 		return 1
 	}
 }
 
+// GetLevelBonus returns the chips and multiplier each level above the first
+// adds to the hand type
+func (ht HandType) GetLevelBonus() (int, int) {
+	switch ht {
+	case HighCard:
+		return 10, 1
+	case Pair:
+		return 15, 1
+	case TwoPair:
+		return 20, 1
+	case ThreeOfAKind:
+		return 20, 2
+	case Straight:
+		return 30, 3
+	case Flush:
+		return 15, 2
+	case FullHouse:
+		return 25, 2
+	case FourOfAKind:
+		return 30, 3
+	case StraightFlush, RoyalFlush:
+		return 40, 4
+	case FiveOfAKind:
+		return 35, 3
+	case FlushHouse:
+		return 40, 4
+	case FlushFive:
+		return 50, 3
+	default:
+		return 0, 0
+	}
+}
+
+// HandLevels records the level of each hand type, raised by planet cards.
+// Hand types that are missing are level 1.
+type HandLevels map[HandType]int
+
+func (hl HandLevels) Level(ht HandType) int {
+	// A Royal Flush is levelled up along with the Straight Flush
+	if ht == RoyalFlush {
+		ht = StraightFlush
+	}
+	if level, ok := hl[ht]; ok && level > 1 {
+		return level
+	}
+	return 1
+}
+
+func (hl HandLevels) LevelUp(ht HandType) {
+	if ht == RoyalFlush {
+		ht = StraightFlush
+	}
+	hl[ht] = hl.Level(ht) + 1
+}
+
 type HandEvaluation struct {
 	Type       HandType
 	Multiplier int
 	CardValue  int
 	TotalScore int
+	// Mult is the multiplier after card effects have been applied on top of
+	// the hand's base Multiplier
+	Mult float64
+	// Dollars is the money earned while scoring (Gold Seals, Lucky cards)
+	Dollars int
+	// Shattered holds the indexes of played Glass cards that broke
+	Shattered []int
+	// NotAllowed is set when the boss blind forbids the hand type, which
+	// then scores nothing
+	NotAllowed bool
+}
+
+// ScoringContext carries the state outside of the played cards that affects
+// how a hand scores
+type ScoringContext struct {
+	// Held are the cards left in hand, which trigger Steel cards
+	Held Hand
+	// Rand drives chance effects such as Lucky and Glass cards. A nil Rand
+	// never triggers them, which keeps previews deterministic.
+	Rand *rand.Rand
+	// Levels are the hand levels bought with planet cards
+	Levels HandLevels
+	// Jokers apply their effects, left to right, after the cards have scored
+	Jokers []Joker
+	// Discards is the number of discards remaining in the blind
+	Discards int
+	// Boss is the rule of the active boss blind, if any
+	Boss BossKind
+	// PlayedHands are the hand types already played this blind
+	PlayedHands []HandType
+}
+
+// rules collects the hand rules granted by the jokers in play
+func (ctx ScoringContext) rules() HandRules {
+	var rules HandRules
+	for _, joker := range ctx.Jokers {
+		switch joker.Kind {
+		case FourFingers:
+			rules.FourFingers = true
+		case Shortcut:
+			rules.Shortcut = true
+		}
+	}
+	return rules
+}
+
+func (ctx ScoringContext) chance(odds int) bool {
+	return ctx.Rand != nil && ctx.Rand.Intn(odds) == 0
 }
 
 func EvaluateHand(hand Hand) HandEvaluation {
+	return EvaluateHandWithContext(hand, ScoringContext{})
+}
+
+// EvaluateHandWithContext scores the played cards one at a time, the way
+// Balatro does: the hand's level sets the starting chips and multiplier, each
+// card adds its chips and then its enhancement and edition effects, Red Seals
+// retrigger a card, Steel cards held in hand multiply the result and finally
+// every joker applies its effect. Cards debuffed by the boss blind add nothing.
+func EvaluateHandWithContext(hand Hand, ctx ScoringContext) HandEvaluation {
 	if len(hand) == 0 {
 		return HandEvaluation{
 			Type:       HighCard,
 			Multiplier: 1,
 			CardValue:  0,
 			TotalScore: 0,
+			Mult:       1,
 		}
 	}
 
-	handType := determineHandType(hand)
-	multiplier := handType.GetBaseMultiplier()
-	cardValue := hand.GetTotalValue()
-	totalScore := cardValue * multiplier
-
-	return HandEvaluation{
+	handType := determineHandTypeWithRules(hand, ctx.rules())
+	levelChips, levelMult := handType.GetLevelBonus()
+	extraLevels := ctx.Levels.Level(handType) - 1
+	chips, multiplier := ctx.Boss.adjustBase(extraLevels*levelChips, handType.GetBaseMultiplier()+extraLevels*levelMult)
+	evaluation := HandEvaluation{
 		Type:       handType,
 		Multiplier: multiplier,
-		CardValue:  cardValue,
-		TotalScore: totalScore,
+		CardValue:  chips,
+		Mult:       float64(multiplier),
 	}
-}
-
-func determineHandType(hand Hand) HandType {
-	if len(hand) < 2 {
-		return HighCard
+	if !ctx.Boss.Allows(handType, ctx.PlayedHands) {
+		evaluation.NotAllowed = true
+		return evaluation
 	}
 
-	// Sort hand for easier evaluation
-	sortedHand := make(Hand, len(hand))
-	copy(sortedHand, hand)
-	sortedHand.Sort()
-
-	isFlush := checkFlush(sortedHand)
-	isStraight := checkStraight(sortedHand)
-	
-	if isFlush && isStraight {
-		if isRoyalFlush(sortedHand) {
-			return RoyalFlush
+	for idx, card := range hand {
+		if ctx.Boss.Debuffs(card) {
+			continue
+		}
+		for trigger := 0; trigger < card.triggers(); trigger++ {
+			evaluation.scoreCard(card, ctx)
+		}
+		if card.Enhancement == GlassCard && ctx.chance(4) {
+			evaluation.Shattered = append(evaluation.Shattered, idx)
 		}
-		return StraightFlush
 	}
 
-	rankCounts := getRankCounts(sortedHand)
-	counts := make([]int, 0)
-	for _, count := range rankCounts {
-		counts = append(counts, count)
+	for _, card := range ctx.Held {
+		if card.Enhancement != SteelCard || ctx.Boss.Debuffs(card) {
+			continue
+		}
+		for trigger := 0; trigger < card.triggers(); trigger++ {
+			evaluation.Mult *= 1.5
+		}
 	}
-	sort.Sort(sort.Reverse(sort.IntSlice(counts)))
 
-	if len(counts) > 0 && counts[0] == 4 {
-		return FourOfAKind
+	for _, joker := range ctx.Jokers {
+		joker.apply(&evaluation, hand, ctx)
 	}
-	if len(counts) >= 2 && counts[0] == 3 && counts[1] == 2 {
-		return FullHouse
-	}
-	if isFlush {
-		return Flush
-	}
-	if isStraight {
-		return Straight
+
+	evaluation.TotalScore = int(float64(evaluation.CardValue) * evaluation.Mult)
+	return evaluation
+}
+
+func (c Card) triggers() int {
+	if c.Seal == RedSeal {
+		return 2
 	}
-	if len(counts) > 0 && counts[0] == 3 {
-		return ThreeOfAKind
+	return 1
+}
+
+func (e *HandEvaluation) scoreCard(card Card, ctx ScoringContext) {
+	e.CardValue += card.GetValue()
+
+	switch card.Enhancement {
+	case BonusCard:
+		e.CardValue += 30
+	case MultCard:
+		e.Mult += 4
+	case GlassCard:
+		e.Mult *= 2
+	case LuckyCard:
+		if ctx.chance(5) {
+			e.Mult += 20
+		}
+		if ctx.chance(15) {
+			e.Dollars += 20
+		}
 	}
-	if len(counts) >= 2 && counts[0] == 2 && counts[1] == 2 {
-		return TwoPair
+
+	switch card.Edition {
+	case Foil:
+		e.CardValue += 50
+	case Holographic:
+		e.Mult += 10
+	case Polychrome:
+		e.Mult *= 1.5
 	}
-	if len(counts) > 0 && counts[0] == 2 {
-		return Pair
+
+	if card.Seal == GoldSeal {
+		e.Dollars += 3
 	}
+}
+
+// HandRules are joker effects that change which hands can be formed
+type HandRules struct {
+	// FourFingers lets Flushes and Straights be made with 4 cards
+	FourFingers bool
+	// Shortcut lets Straights skip a single rank, e.g. 3 5 6 8 10
+	Shortcut bool
+}
 
-	return HighCard
+// handShape summarizes the ranks and suits of up to five played cards. It is
+// built without allocating so hands can be evaluated in bulk.
+type handShape struct {
+	// counts holds the number of ranked cards of each rank
+	counts [Ace + 1]int
+	// top and second are the two largest rank counts
+	top, second int
+	// pairs is the number of ranks with at least two cards
+	pairs    int
+	flush    bool
+	straight bool
+	royal    bool
 }
 
-func checkFlush(hand Hand) bool {
-	if len(hand) < 5 {
-		return false
+// royalRanks is the set of ranks in a Royal Flush, as a rank bitmask
+const royalRanks = 1<<Ten | 1<<Jack | 1<<Queen | 1<<King | 1<<Ace
+
+func analyzeHand(hand Hand, rules HandRules) handShape {
+	var shape handShape
+	needed := 5
+	if rules.FourFingers {
+		needed = 4
 	}
-	suit := hand[0].Suit
+
+	var suitCounts [Spades + 1]int
+	rankMask := 0
 	for _, card := range hand {
-		if card.Suit != suit {
-			return false
+		for suit := Hearts; suit <= Spades; suit++ {
+			if card.IsSuit(suit) {
+				suitCounts[suit]++
+			}
+		}
+		if card.HasRank() {
+			shape.counts[card.Rank]++
+			rankMask |= 1 << card.Rank
 		}
 	}
-	return true
-}
 
-func checkStraight(hand Hand) bool {
-	if len(hand) < 5 {
-		return false
+	for _, count := range suitCounts {
+		if count >= needed {
+			shape.flush = true
+		}
 	}
-	
-	// Check for regular straight
-	for i := 1; i < len(hand); i++ {
-		if int(hand[i].Rank) != int(hand[i-1].Rank)+1 {
-			// Check for ace-low straight (A-2-3-4-5)
-			if i == len(hand)-1 && hand[0].Rank == Two && hand[len(hand)-1].Rank == Ace {
-				continue
-			}
-			return false
+
+	for rank := Two; rank <= Ace; rank++ {
+		count := shape.counts[rank]
+		if count >= 2 {
+			shape.pairs++
+		}
+		if count > shape.top {
+			shape.second = shape.top
+			shape.top = count
+		} else if count > shape.second {
+			shape.second = count
 		}
 	}
-	return true
+
+	shape.straight = longestStraight(rankMask, rules.Shortcut) >= needed
+	shape.royal = shape.straight && rankMask&royalRanks == royalRanks
+	return shape
 }
 
-func isRoyalFlush(hand Hand) bool {
-	if len(hand) != 5 {
-		return false
+// longestStraight returns the most cards that can be chained into a
+// straight from the ranks in the bitmask. Aces play both high and low, but
+// a straight can't wrap around from King to Two.
+func longestStraight(rankMask int, shortcut bool) int {
+	// Bit 1 stands for an ace played low
+	if rankMask&(1<<Ace) != 0 {
+		rankMask |= 1 << 1
+	}
+
+	// run[r] is the longest straight starting at rank r and going up
+	var run [Ace + 3]int
+	longest := 0
+	for rank := int(Ace); rank >= 1; rank-- {
+		if rankMask&(1<<rank) == 0 {
+			continue
+		}
+		next := run[rank+1]
+		if shortcut && run[rank+2] > next {
+			next = run[rank+2]
+		}
+		run[rank] = 1 + next
+		if run[rank] > longest {
+			longest = run[rank]
+		}
 	}
-	return hand[0].Rank == Ten && hand[4].Rank == Ace
+	return longest
 }
 
-func getRankCounts(hand Hand) map[Rank]int {
-	counts := make(map[Rank]int)
-	for _, card := range hand {
-		counts[card.Rank]++
+func determineHandType(hand Hand) HandType {
+	return determineHandTypeWithRules(hand, HandRules{})
+}
+
+// determineHandTypeWithRules finds the best hand type the played cards make,
+// from the secret hands down to a High Card
+func determineHandTypeWithRules(hand Hand, rules HandRules) HandType {
+	shape := analyzeHand(hand, rules)
+	return shape.handType()
+}
+
+func (shape handShape) handType() HandType {
+	isFullHouse := shape.top == 3 && shape.second >= 2
+
+	switch {
+	case shape.top >= 5 && shape.flush:
+		return FlushFive
+	case isFullHouse && shape.flush:
+		return FlushHouse
+	case shape.top >= 5:
+		return FiveOfAKind
+	case shape.straight && shape.flush && shape.royal:
+		return RoyalFlush
+	case shape.straight && shape.flush:
+		return StraightFlush
+	case shape.top == 4:
+		return FourOfAKind
+	case isFullHouse:
+		return FullHouse
+	case shape.flush:
+		return Flush
+	case shape.straight:
+		return Straight
+	case shape.top == 3:
+		return ThreeOfAKind
+	case shape.pairs >= 2:
+		return TwoPair
+	case shape.top == 2:
+		return Pair
+	default:
+		return HighCard
 	}
-	return counts
-}
\ No newline at end of file
+}
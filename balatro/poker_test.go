@@ -31,8 +31,8 @@ func TestCardValues(t *testing.T) {
 func TestHandEvaluation(t *testing.T) {
 	// Test Pair
 	pairHand := Hand{
-		{Hearts, Ace},
-		{Spades, Ace},
+		{Suit: Hearts, Rank: Ace},
+		{Suit: Spades, Rank: Ace},
 	}
 	eval := EvaluateHand(pairHand)
 	if eval.Type != Pair {
@@ -50,9 +50,9 @@ func TestHandEvaluation(t *testing.T) {
 
 	// Test Three of a Kind
 	threeKindHand := Hand{
-		{Hearts, King},
-		{Spades, King},
-		{Clubs, King},
+		{Suit: Hearts, Rank: King},
+		{Suit: Spades, Rank: King},
+		{Suit: Clubs, Rank: King},
 	}
 	eval = EvaluateHand(threeKindHand)
 	if eval.Type != ThreeOfAKind {
@@ -67,11 +67,11 @@ func TestHandEvaluation(t *testing.T) {
 
 	// Test Flush
 	flushHand := Hand{
-		{Hearts, Two},
-		{Hearts, Four},
-		{Hearts, Six},
-		{Hearts, Eight},
-		{Hearts, Ten},
+		{Suit: Hearts, Rank: Two},
+		{Suit: Hearts, Rank: Four},
+		{Suit: Hearts, Rank: Six},
+		{Suit: Hearts, Rank: Eight},
+		{Suit: Hearts, Rank: Ten},
 	}
 	eval = EvaluateHand(flushHand)
 	if eval.Type != Flush {
@@ -87,11 +87,11 @@ func TestHandEvaluation(t *testing.T) {
 
 	// Test Straight
 	straightHand := Hand{
-		{Hearts, Two},
-		{Spades, Three},
-		{Clubs, Four},
-		{Diamonds, Five},
-		{Hearts, Six},
+		{Suit: Hearts, Rank: Two},
+		{Suit: Spades, Rank: Three},
+		{Suit: Clubs, Rank: Four},
+		{Suit: Diamonds, Rank: Five},
+		{Suit: Hearts, Rank: Six},
 	}
 	eval = EvaluateHand(straightHand)
 	if eval.Type != Straight {
@@ -127,4 +127,291 @@ func TestDeckCreation(t *testing.T) {
 			t.Errorf("Expected 4 cards of rank %s, got %d", rank, rankCounts[rank])
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestCardString(t *testing.T) {
+	tests := []struct {
+		card     Card
+		expected string
+	}{
+		{Card{Suit: Hearts, Rank: Ace}, "A♥"},
+		{Card{Suit: Spades, Rank: King, Enhancement: GlassCard}, "K♠[Glass]"},
+		{Card{Suit: Clubs, Rank: Ten, Edition: Foil, Seal: RedSeal}, "10♣[Foil,Red Seal]"},
+		{Card{Suit: Diamonds, Rank: Two, Enhancement: StoneCard, Seal: GoldSeal}, "Stone[Gold Seal]"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.card.String(); got != tt.expected {
+			t.Errorf("Expected %q, got %q", tt.expected, got)
+		}
+	}
+}
+
+func TestWildCardFlush(t *testing.T) {
+	hand := Hand{
+		{Suit: Hearts, Rank: Two},
+		{Suit: Hearts, Rank: Four},
+		{Suit: Spades, Rank: Six, Enhancement: WildCard},
+		{Suit: Hearts, Rank: Eight},
+		{Suit: Clubs, Rank: Ten, Enhancement: WildCard},
+	}
+	if eval := EvaluateHand(hand); eval.Type != Flush {
+		t.Errorf("Expected Flush with wild cards, got %s", eval.Type)
+	}
+}
+
+func TestStoneCard(t *testing.T) {
+	// The Stone card has no rank, so the Aces stay a Pair, and no suit, so
+	// four hearts and a Stone are not a Flush
+	hand := Hand{
+		{Suit: Hearts, Rank: Ace},
+		{Suit: Hearts, Rank: Ace},
+		{Suit: Hearts, Rank: Ace, Enhancement: StoneCard},
+		{Suit: Hearts, Rank: Four},
+		{Suit: Hearts, Rank: Five},
+	}
+	eval := EvaluateHand(hand)
+	if eval.Type != Pair {
+		t.Errorf("Expected Pair, got %s", eval.Type)
+	}
+	expectedValue := 11 + 11 + 50 + 4 + 5
+	if eval.CardValue != expectedValue {
+		t.Errorf("Expected card value %d, got %d", expectedValue, eval.CardValue)
+	}
+}
+
+func TestEnhancementScoring(t *testing.T) {
+	hand := Hand{
+		{Suit: Hearts, Rank: Ace, Enhancement: BonusCard},
+		{Suit: Spades, Rank: Ace, Enhancement: MultCard, Seal: RedSeal},
+	}
+	held := Hand{{Suit: Clubs, Rank: Two, Enhancement: SteelCard}}
+	eval := EvaluateHandWithContext(hand, ScoringContext{Held: held})
+
+	// Chips: 11 + 30 from the Bonus card, 11 twice from the retriggered Ace
+	if eval.CardValue != 63 {
+		t.Errorf("Expected card value 63, got %d", eval.CardValue)
+	}
+	// Mult: 2 base + 4 twice, then x1.5 for the held Steel card
+	if eval.Mult != 15 {
+		t.Errorf("Expected mult 15, got %.1f", eval.Mult)
+	}
+	if eval.TotalScore != 945 {
+		t.Errorf("Expected total score 945, got %d", eval.TotalScore)
+	}
+
+	glass := Hand{{Suit: Hearts, Rank: Ten, Enhancement: GlassCard, Edition: Polychrome, Seal: GoldSeal}}
+	eval = EvaluateHand(glass)
+	if eval.Mult != 3 {
+		t.Errorf("Expected mult 3, got %.1f", eval.Mult)
+	}
+	if eval.Dollars != 3 {
+		t.Errorf("Expected $3 from the Gold Seal, got %d", eval.Dollars)
+	}
+	if len(eval.Shattered) != 0 {
+		t.Errorf("Glass should never shatter without a Rand, got %v", eval.Shattered)
+	}
+}
+
+func TestSecretHands(t *testing.T) {
+	tests := []struct {
+		name     string
+		hand     Hand
+		expected HandType
+	}{
+		{
+			name: "five of a kind",
+			hand: Hand{
+				{Suit: Hearts, Rank: Ace}, {Suit: Spades, Rank: Ace}, {Suit: Spades, Rank: Ace},
+				{Suit: Clubs, Rank: Ace}, {Suit: Diamonds, Rank: Ace},
+			},
+			expected: FiveOfAKind,
+		},
+		{
+			name: "flush house",
+			hand: Hand{
+				{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Ace},
+				{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: King},
+			},
+			expected: FlushHouse,
+		},
+		{
+			name: "flush five",
+			hand: Hand{
+				{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Ace},
+				{Suit: Hearts, Rank: Ace}, {Suit: Hearts, Rank: Ace},
+			},
+			expected: FlushFive,
+		},
+		{
+			name: "four of a kind plus a kicker is not five of a kind",
+			hand: Hand{
+				{Suit: Hearts, Rank: Ace}, {Suit: Spades, Rank: Ace}, {Suit: Clubs, Rank: Ace},
+				{Suit: Diamonds, Rank: Ace}, {Suit: Hearts, Rank: King},
+			},
+			expected: FourOfAKind,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eval := EvaluateHand(tt.hand)
+			if eval.Type != tt.expected {
+				t.Errorf("Expected %s, got %s for %s", tt.expected, eval.Type, tt.hand)
+			}
+			if eval.Multiplier != tt.expected.GetBaseMultiplier() {
+				t.Errorf("Expected multiplier %d, got %d", tt.expected.GetBaseMultiplier(), eval.Multiplier)
+			}
+		})
+	}
+}
+
+func TestSecretHandsOutrankStandardHands(t *testing.T) {
+	secret := []HandType{FiveOfAKind, FlushHouse, FlushFive}
+	for idx, handType := range secret {
+		if handType.GetBaseMultiplier() <= RoyalFlush.GetBaseMultiplier() {
+			t.Errorf("%s should be worth more than a Royal Flush", handType)
+		}
+		if idx > 0 && handType.GetBaseMultiplier() <= secret[idx-1].GetBaseMultiplier() {
+			t.Errorf("%s should be worth more than %s", handType, secret[idx-1])
+		}
+	}
+
+	// A wild card can turn a five of a kind into a Flush Five
+	hand := Hand{
+		{Suit: Hearts, Rank: Seven},
+		{Suit: Hearts, Rank: Seven},
+		{Suit: Hearts, Rank: Seven},
+		{Suit: Hearts, Rank: Seven},
+		{Suit: Spades, Rank: Seven, Enhancement: WildCard},
+	}
+	if eval := EvaluateHand(hand); eval.Type != FlushFive {
+		t.Errorf("Expected Flush Five, got %s", eval.Type)
+	}
+}
+
+// forEachCombination calls fn with every k-card hand from the cards
+func forEachCombination(cards []Card, k int, fn func(Hand)) {
+	hand := make(Hand, k)
+	var choose func(start, depth int)
+	choose = func(start, depth int) {
+		if depth == k {
+			fn(hand)
+			return
+		}
+		for i := start; i <= len(cards)-(k-depth); i++ {
+			hand[depth] = cards[i]
+			choose(i+1, depth+1)
+		}
+	}
+	choose(0, 0)
+}
+
+func TestHandFrequencies(t *testing.T) {
+	// Known counts of every hand category for each selection size from a
+	// standard 52-card deck
+	tests := []struct {
+		size     int
+		expected map[HandType]int
+	}{
+		{1, map[HandType]int{HighCard: 52}},
+		{2, map[HandType]int{Pair: 78, HighCard: 1248}},
+		{3, map[HandType]int{ThreeOfAKind: 52, Pair: 3744, HighCard: 18304}},
+		{4, map[HandType]int{FourOfAKind: 13, ThreeOfAKind: 2496, TwoPair: 2808, Pair: 82368, HighCard: 183040}},
+		{5, map[HandType]int{
+			RoyalFlush:    4,
+			StraightFlush: 36,
+			FourOfAKind:   624,
+			FullHouse:     3744,
+			Flush:         5108,
+			Straight:      10200,
+			ThreeOfAKind:  54912,
+			TwoPair:       123552,
+			Pair:          1098240,
+			HighCard:      1302540,
+		}},
+	}
+
+	deck := NewDeck()
+	for _, tt := range tests {
+		if tt.size == 5 && testing.Short() {
+			continue
+		}
+		counts := make(map[HandType]int)
+		forEachCombination(deck.Cards, tt.size, func(hand Hand) {
+			counts[determineHandType(hand)]++
+		})
+		for handType := HighCard; handType <= FlushFive; handType++ {
+			if counts[handType] != tt.expected[handType] {
+				t.Errorf("%d cards: expected %d %s hands, got %d", tt.size, tt.expected[handType], handType, counts[handType])
+			}
+		}
+	}
+}
+
+func TestStraightEdgeCases(t *testing.T) {
+	hand := func(ranks ...Rank) Hand {
+		h := Hand{}
+		for idx, rank := range ranks {
+			h = append(h, Card{Suit: Suit(idx % 2), Rank: rank})
+		}
+		return h
+	}
+	suited := func(h Hand) Hand {
+		for idx := range h {
+			h[idx].Suit = Spades
+		}
+		return h
+	}
+
+	tests := []struct {
+		name     string
+		hand     Hand
+		rules    HandRules
+		expected HandType
+	}{
+		{"wheel in any order", hand(Ace, Three, Five, Two, Four), HandRules{}, Straight},
+		{"wheel straight flush is not royal", suited(hand(Five, Ace, Four, Three, Two)), HandRules{}, StraightFlush},
+		{"broadway", hand(King, Ace, Jack, Ten, Queen), HandRules{}, Straight},
+		{"royal flush", suited(hand(Ace, King, Queen, Jack, Ten)), HandRules{}, RoyalFlush},
+		{"no wrap around", hand(Queen, King, Ace, Two, Three), HandRules{}, HighCard},
+		{"four card straight needs Four Fingers", hand(Six, Seven, Eight, Nine, King), HandRules{}, HighCard},
+		{"Four Fingers straight", hand(Six, Seven, Eight, Nine, King), HandRules{FourFingers: true}, Straight},
+		{"Four Fingers straight from four cards", hand(Ace, Two, Three, Four), HandRules{FourFingers: true}, Straight},
+		{"Four Fingers flush", Hand{
+			{Suit: Hearts, Rank: Two}, {Suit: Hearts, Rank: Six}, {Suit: Hearts, Rank: Nine},
+			{Suit: Hearts, Rank: Queen}, {Suit: Spades, Rank: King},
+		}, HandRules{FourFingers: true}, Flush},
+		{"gapped straight needs Shortcut", hand(Three, Five, Six, Eight, Ten), HandRules{}, HighCard},
+		{"Shortcut straight", hand(Three, Five, Six, Eight, Ten), HandRules{Shortcut: true}, Straight},
+		{"Shortcut can't skip two ranks", hand(Two, Five, Six, Eight, Ten), HandRules{Shortcut: true}, HighCard},
+		{"Shortcut ace low", hand(Ace, Three, Four, Six, Seven), HandRules{Shortcut: true}, Straight},
+		{"Four Fingers and Shortcut", hand(Two, Four, Six, Eight, King), HandRules{FourFingers: true, Shortcut: true}, Straight},
+		{"pair beats a four card straight without Four Fingers", hand(Six, Seven, Eight, Nine, Nine), HandRules{}, Pair},
+		{"Four Fingers straight beats a pair", hand(Six, Seven, Eight, Nine, Nine), HandRules{FourFingers: true}, Straight},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := determineHandTypeWithRules(tt.hand, tt.rules); got != tt.expected {
+				t.Errorf("Expected %s, got %s for %s", tt.expected, got, tt.hand)
+			}
+		})
+	}
+}
+
+func TestJokerRules(t *testing.T) {
+	hand := Hand{
+		{Suit: Hearts, Rank: Two}, {Suit: Hearts, Rank: Six}, {Suit: Hearts, Rank: Nine}, {Suit: Hearts, Rank: Queen},
+	}
+	if eval := EvaluateHand(hand); eval.Type != HighCard {
+		t.Errorf("Expected High Card without Four Fingers, got %s", eval.Type)
+	}
+	eval := EvaluateHandWithContext(hand, ScoringContext{Jokers: []Joker{{Kind: FourFingers}, {Kind: DrollJoker}}})
+	if eval.Type != Flush {
+		t.Errorf("Expected Flush with Four Fingers, got %s", eval.Type)
+	}
+	if eval.Mult != float64(Flush.GetBaseMultiplier()+10) {
+		t.Errorf("Expected Droll Joker to see the 4 card Flush, got mult %.1f", eval.Mult)
+	}
+}
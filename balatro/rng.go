@@ -0,0 +1,33 @@
+package balatro
+
+import (
+	"math/rand"
+)
+
+// rngSource is a splitmix64 generator. Unlike the math/rand sources its whole
+// state is one number, so a run's randomness can be saved and restored.
+type rngSource struct {
+	state uint64
+}
+
+func newRand(seed int64) (*rand.Rand, *rngSource) {
+	source := &rngSource{}
+	source.Seed(seed)
+	return rand.New(source), source
+}
+
+func (s *rngSource) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+func (s *rngSource) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *rngSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
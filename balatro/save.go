@@ -0,0 +1,170 @@
+package balatro
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// SaveVersion is bumped whenever the save format changes incompatibly
+const SaveVersion = 2
+
+// savedGame is the on-disk form of a run in progress
+type savedGame struct {
+	Version      int          `json:"version"`
+	Config       RunConfig    `json:"config"`
+	Seed         string       `json:"seed"`
+	RNGState     uint64       `json:"rng_state"`
+	FullDeck     []Card       `json:"full_deck"`
+	DrawPile     []Card       `json:"draw_pile"`
+	Hand         []Card       `json:"hand"`
+	Score        int          `json:"score"`
+	Round        int          `json:"round"`
+	Ante         int          `json:"ante"`
+	Blind        BlindKind    `json:"blind"`
+	Hands        int          `json:"hands"`
+	Discards     int          `json:"discards"`
+	Money        int          `json:"money"`
+	Jokers       []Joker      `json:"jokers"`
+	Consumables  []Consumable `json:"consumables"`
+	HandLevels   HandLevels   `json:"hand_levels"`
+	LastHandType HandType     `json:"last_hand_type"`
+	Boss         BossKind     `json:"boss"`
+	PlayedHands  []HandType   `json:"played_hands"`
+	Shop         *Shop        `json:"shop,omitempty"`
+	Over         bool         `json:"over"`
+	Won          bool         `json:"won"`
+}
+
+// DefaultSavePath returns where the CLI keeps its save file
+func DefaultSavePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "balatro_save.json"
+	}
+	return filepath.Join(dir, "balatro-cli", "save.json")
+}
+
+// MarshalGame encodes the complete state of a run, including the random
+// number generator, so a loaded run plays out exactly as the original would
+func MarshalGame(g *Game) ([]byte, error) {
+	saved := savedGame{
+		Version:      SaveVersion,
+		Config:       g.Config,
+		Seed:         g.Seed,
+		RNGState:     g.source.state,
+		FullDeck:     g.FullDeck.Cards,
+		DrawPile:     g.Deck.Cards,
+		Hand:         g.PlayerHand,
+		Score:        g.Score,
+		Round:        g.Round,
+		Ante:         g.Ante,
+		Blind:        g.Blind,
+		Hands:        g.Hands,
+		Discards:     g.Discards,
+		Money:        g.Money,
+		Jokers:       g.Jokers,
+		Consumables:  g.Consumables,
+		HandLevels:   g.HandLevels,
+		LastHandType: g.LastHandType,
+		Boss:         g.Boss,
+		PlayedHands:  g.PlayedHands,
+		Shop:         g.Shop,
+		Over:         g.Over,
+		Won:          g.Won,
+	}
+	return json.MarshalIndent(saved, "", "  ")
+}
+
+// UnmarshalGame restores a run encoded by MarshalGame
+func UnmarshalGame(data []byte) (*Game, error) {
+	var saved savedGame
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("reading save: %w", err)
+	}
+	if saved.Version != SaveVersion {
+		return nil, fmt.Errorf("save version %d is not supported (expected %d)", saved.Version, SaveVersion)
+	}
+
+	g := &Game{
+		Config:       saved.Config,
+		Seed:         saved.Seed,
+		FullDeck:     &Deck{Cards: saved.FullDeck},
+		Deck:         &Deck{Cards: saved.DrawPile},
+		PlayerHand:   saved.Hand,
+		Score:        saved.Score,
+		Round:        saved.Round,
+		Ante:         saved.Ante,
+		Blind:        saved.Blind,
+		Hands:        saved.Hands,
+		Discards:     saved.Discards,
+		Money:        saved.Money,
+		Jokers:       saved.Jokers,
+		Consumables:  saved.Consumables,
+		HandLevels:   saved.HandLevels,
+		LastHandType: saved.LastHandType,
+		Boss:         saved.Boss,
+		PlayedHands:  saved.PlayedHands,
+		Shop:         saved.Shop,
+		Over:         saved.Over,
+		Won:          saved.Won,
+	}
+	if g.PlayerHand == nil {
+		g.PlayerHand = make([]Card, 0)
+	}
+	if g.HandLevels == nil {
+		g.HandLevels = HandLevels{}
+	}
+	g.rng, g.source = newRand(0)
+	g.source.state = saved.RNGState
+	return g, nil
+}
+
+// SaveGame writes the run to path, replacing any earlier save
+func SaveGame(g *Game, path string) error {
+	data, err := MarshalGame(g)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so a crash can't leave a half written save
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadGame reads a run saved with SaveGame. Later saves go back to the same path.
+func LoadGame(path string) (*Game, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	g, err := UnmarshalGame(data)
+	if err != nil {
+		return nil, err
+	}
+	g.SavePath = path
+	return g, nil
+}
+
+// autosave saves the run to SavePath. A finished run removes its save so it
+// can't be continued.
+func (g *Game) autosave() {
+	if g.SavePath == "" {
+		return
+	}
+	if g.Over {
+		if err := os.Remove(g.SavePath); err != nil && !os.IsNotExist(err) {
+			fmt.Println("Could not remove the save file:", err)
+		}
+		return
+	}
+	if err := SaveGame(g, g.SavePath); err != nil {
+		fmt.Println("Could not save the run:", err)
+	}
+}
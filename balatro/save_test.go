@@ -0,0 +1,102 @@
+package balatro
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+)
+
+// step takes one action chosen only from the game's state, so two games in
+// the same state take the same action
+func step(g *Game) {
+	switch {
+	case g.Shop != nil && len(g.Shop.Pack) > 0:
+		if _, err := g.ChoosePackItem(0); err != nil {
+			g.ChoosePackItem(-1)
+		}
+	case g.Shop != nil:
+		if g.Shop.RerollCost == baseRerollCost && g.Money >= baseRerollCost+4 {
+			g.Reroll()
+			return
+		}
+		for idx := range g.Shop.Items {
+			if _, err := g.Buy(idx); err == nil {
+				return
+			}
+		}
+		g.NextBlind()
+	case g.Discards > 0 && g.Hands < g.MaxHands():
+		g.Discard([]int{0, 1, 2})
+	default:
+		g.PlayHand([]int{0, 1, 2, 3, 4})
+		if g.BlindCleared() {
+			g.CashOut()
+		} else if g.BlindLost() {
+			g.Over = true
+		}
+	}
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	g := NewGame(RunConfig{Deck: ErraticDeck, Stake: OrangeStake, Seed: "SEED21"})
+	g.Money = 40
+	g.Jokers = []Joker{{Kind: PlainJoker, Edition: Foil}, {Kind: Cavendish}, {Kind: GoldenJoker, Perishable: true, RoundsLeft: 2}}
+	g.Consumables = []Consumable{{Kind: TheHermit}}
+	g.HandLevels.LevelUp(Pair)
+	for i := 0; i < 10; i++ {
+		step(g)
+	}
+
+	data, err := MarshalGame(g)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	loaded, err := UnmarshalGame(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	again, _ := MarshalGame(loaded)
+	if !bytes.Equal(data, again) {
+		t.Fatalf("Expected the loaded game to save identically\nwant %s\ngot  %s", data, again)
+	}
+
+	for i := 0; i < 60 && !g.Over; i++ {
+		step(g)
+		step(loaded)
+		want, _ := MarshalGame(g)
+		got, _ := MarshalGame(loaded)
+		if !bytes.Equal(want, got) {
+			t.Fatalf("Games diverged after %d actions\nwant %s\ngot  %s", i+1, want, got)
+		}
+	}
+}
+
+func TestSaveFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "runs", "save.json")
+	g := newTestGame(22)
+	g.SavePath = path
+	g.autosave()
+
+	loaded, err := LoadGame(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if loaded.SavePath != path || len(loaded.PlayerHand) != HandSize || loaded.Seed != g.Seed {
+		t.Errorf("Expected the saved run back, got %+v", loaded)
+	}
+
+	g.Over = true
+	g.autosave()
+	if _, err := LoadGame(path); err == nil {
+		t.Error("Expected a finished run to remove its save")
+	}
+}
+
+func TestSaveVersion(t *testing.T) {
+	if _, err := UnmarshalGame([]byte(`{"version": 99}`)); err == nil {
+		t.Error("Expected an unknown save version to fail")
+	}
+	if _, err := UnmarshalGame([]byte(`not json`)); err == nil {
+		t.Error("Expected invalid JSON to fail")
+	}
+}
@@ -0,0 +1,53 @@
+package balatro
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+)
+
+// SeedLength is the length of generated run seeds
+const SeedLength = 8
+
+// seedAlphabet leaves out 0 so it can't be mistaken for O when a seed is shared
+const seedAlphabet = "123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// RandomSeed returns a new seed from the clock
+func RandomSeed() string {
+	return seedFromNumber(uint64(time.Now().UnixNano()))
+}
+
+// seedFromNumber spells a number as a seed
+func seedFromNumber(n uint64) string {
+	var seed [SeedLength]byte
+	for i := range seed {
+		seed[i] = seedAlphabet[n%uint64(len(seedAlphabet))]
+		n /= uint64(len(seedAlphabet))
+	}
+	return string(seed[:])
+}
+
+// ParseSeed checks a seed typed by a player and returns it in upper case
+func ParseSeed(input string) (string, error) {
+	seed := strings.ToUpper(strings.TrimSpace(input))
+	if seed == "" || len(seed) > SeedLength {
+		return "", fmt.Errorf("a seed is 1 to %d letters and digits", SeedLength)
+	}
+	for _, c := range seed {
+		if c == '0' {
+			return "", fmt.Errorf("seeds don't use 0, did you mean O?")
+		}
+		if !strings.ContainsRune(seedAlphabet, c) {
+			return "", fmt.Errorf("seeds only use letters and digits, not %q", c)
+		}
+	}
+	return seed, nil
+}
+
+// seedNumber turns a seed into the number the run's random source starts from
+func seedNumber(seed string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(seed))
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,57 @@
+package balatro
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestParseSeed(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"abc12", "ABC12"}, {" 7LUCKY7 ", "7LUCKY7"}, {"ZZZZZZZZ", "ZZZZZZZZ"},
+	}
+	for _, tt := range tests {
+		seed, err := ParseSeed(tt.input)
+		if err != nil || seed != tt.expected {
+			t.Errorf("ParseSeed(%q) = %q, %v, want %q", tt.input, seed, err, tt.expected)
+		}
+	}
+	for _, input := range []string{"", "TOOLONGSEED", "A0", "AB-12"} {
+		if _, err := ParseSeed(input); err == nil {
+			t.Errorf("ParseSeed(%q) should fail", input)
+		}
+	}
+
+	seed := RandomSeed()
+	if parsed, err := ParseSeed(seed); err != nil || parsed != seed || len(seed) != SeedLength {
+		t.Errorf("Expected %q to be a valid %d character seed, got %v", seed, SeedLength, err)
+	}
+}
+
+func TestSameSeedSameRun(t *testing.T) {
+	config := RunConfig{Deck: ErraticDeck, Stake: OrangeStake, Seed: "REPLAY42"}
+	first := NewGame(config)
+	second := NewGame(config)
+	first.Money, second.Money = 50, 50
+
+	// Play both runs with the same choices, comparing every step of their history
+	for i := 0; i < 200 && !first.Over; i++ {
+		step(first)
+		step(second)
+		want, _ := MarshalGame(first)
+		got, _ := MarshalGame(second)
+		if !bytes.Equal(want, got) {
+			t.Fatalf("Runs diverged after %d actions\nwant %s\ngot  %s", i+1, want, got)
+		}
+	}
+	if first.Round == 1 {
+		t.Errorf("Expected the run to get past the first blind, still on round %d", first.Round)
+	}
+
+	other := NewGame(RunConfig{Deck: ErraticDeck, Stake: OrangeStake, Seed: "REPLAY43"})
+	if Hand(other.FullDeck.Cards).String() == Hand(NewGame(config).FullDeck.Cards).String() {
+		t.Error("Expected a different seed to build a different deck")
+	}
+}
@@ -0,0 +1,360 @@
+package balatro
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+const (
+	// MaxJokers is the number of joker slots
+	MaxJokers = 5
+
+	baseRerollCost = 5
+	shopCardSlots  = 2
+	shopPackSlots  = 2
+)
+
+type PackKind int
+
+const (
+	ArcanaPack PackKind = iota
+	CelestialPack
+	BuffoonPack
+	StandardPack
+	SpectralPack
+)
+
+var PackKinds = []PackKind{ArcanaPack, CelestialPack, BuffoonPack, StandardPack, SpectralPack}
+
+func (p PackKind) String() string {
+	switch p {
+	case ArcanaPack:
+		return "Arcana Pack"
+	case CelestialPack:
+		return "Celestial Pack"
+	case BuffoonPack:
+		return "Buffoon Pack"
+	case StandardPack:
+		return "Standard Pack"
+	case SpectralPack:
+		return "Spectral Pack"
+	default:
+		return "Unknown Pack"
+	}
+}
+
+func (p PackKind) Description() string {
+	switch p {
+	case ArcanaPack:
+		return "Choose 1 of 3 Tarot cards"
+	case CelestialPack:
+		return "Choose 1 of 3 Planet cards"
+	case BuffoonPack:
+		return "Choose 1 of 2 Jokers"
+	case StandardPack:
+		return "Choose 1 of 3 playing cards to add to your deck"
+	case SpectralPack:
+		return "Choose 1 of 2 Spectral cards"
+	default:
+		return ""
+	}
+}
+
+func (p PackKind) GetCost() int {
+	return 4
+}
+
+// open rolls the cards offered by the pack
+func (p PackKind) open(rng *rand.Rand, stake Stake) []ShopItem {
+	var contents []ShopItem
+	switch p {
+	case ArcanaPack:
+		for i := 0; i < 3; i++ {
+			contents = append(contents, consumableItem(TarotKinds[rng.Intn(len(TarotKinds))]))
+		}
+	case CelestialPack:
+		for i := 0; i < 3; i++ {
+			contents = append(contents, consumableItem(PlanetKinds[rng.Intn(len(PlanetKinds))]))
+		}
+	case BuffoonPack:
+		for i := 0; i < 2; i++ {
+			contents = append(contents, jokerItem(randomJoker(rng, stake)))
+		}
+	case StandardPack:
+		for i := 0; i < 3; i++ {
+			contents = append(contents, ShopItem{Kind: PlayingCardItem, Card: randomPlayingCard(rng)})
+		}
+	case SpectralPack:
+		for i := 0; i < 2; i++ {
+			contents = append(contents, consumableItem(SpectralKinds[rng.Intn(len(SpectralKinds))]))
+		}
+	}
+	for idx := range contents {
+		contents[idx].Price = 0
+	}
+	return contents
+}
+
+type ShopItemKind int
+
+const (
+	JokerItem ShopItemKind = iota
+	ConsumableItem
+	PackItem
+	// PlayingCardItem only comes out of Standard packs
+	PlayingCardItem
+)
+
+type ShopItem struct {
+	Kind       ShopItemKind
+	Joker      Joker
+	Consumable Consumable
+	Pack       PackKind
+	Card       Card
+	Price      int
+}
+
+func jokerItem(joker Joker) ShopItem {
+	return ShopItem{Kind: JokerItem, Joker: joker, Price: joker.GetCost()}
+}
+
+func consumableItem(kind ConsumableKind) ShopItem {
+	return ShopItem{Kind: ConsumableItem, Consumable: Consumable{Kind: kind}, Price: kind.GetCost()}
+}
+
+func packItem(pack PackKind) ShopItem {
+	return ShopItem{Kind: PackItem, Pack: pack, Price: pack.GetCost()}
+}
+
+func (i ShopItem) String() string {
+	switch i.Kind {
+	case JokerItem:
+		return fmt.Sprintf("%s (%s)", i.Joker, i.Joker.Kind.Description())
+	case ConsumableItem:
+		return fmt.Sprintf("%s (%s)", i.Consumable, i.Consumable.Kind.Description())
+	case PackItem:
+		return fmt.Sprintf("%s (%s)", i.Pack, i.Pack.Description())
+	case PlayingCardItem:
+		return i.Card.String()
+	default:
+		return "Unknown item"
+	}
+}
+
+type Shop struct {
+	Items      []ShopItem
+	RerollCost int
+	// Pack holds the contents of an opened booster pack until one is chosen
+	Pack []ShopItem
+	// Stake decides which joker stickers the shop can roll
+	Stake Stake
+}
+
+func newShop(rng *rand.Rand, stake Stake) *Shop {
+	shop := &Shop{RerollCost: baseRerollCost, Stake: stake}
+	shop.stockCards(rng)
+	for i := 0; i < shopPackSlots; i++ {
+		shop.Items = append(shop.Items, packItem(PackKinds[rng.Intn(len(PackKinds))]))
+	}
+	return shop
+}
+
+// stockCards replaces the jokers and consumables for sale, leaving packs in place
+func (s *Shop) stockCards(rng *rand.Rand) {
+	packs := make([]ShopItem, 0, len(s.Items))
+	for _, item := range s.Items {
+		if item.Kind == PackItem {
+			packs = append(packs, item)
+		}
+	}
+
+	cards := make([]ShopItem, 0, shopCardSlots)
+	for i := 0; i < shopCardSlots; i++ {
+		cards = append(cards, randomShopCard(rng, s.Stake))
+	}
+	s.Items = append(cards, packs...)
+}
+
+// randomShopCard picks a joker, tarot or planet with Balatro's 20:4:4 weights
+func randomShopCard(rng *rand.Rand, stake Stake) ShopItem {
+	roll := rng.Intn(28)
+	switch {
+	case roll < 20:
+		return jokerItem(randomJoker(rng, stake))
+	case roll < 24:
+		return consumableItem(TarotKinds[rng.Intn(len(TarotKinds))])
+	default:
+		return consumableItem(PlanetKinds[rng.Intn(len(PlanetKinds))])
+	}
+}
+
+// randomPlayingCard rolls a card for a Standard pack, which may come with an
+// enhancement, edition or seal
+func randomPlayingCard(rng *rand.Rand) Card {
+	card := Card{
+		Suit: Suit(rng.Intn(4)),
+		Rank: Two + Rank(rng.Intn(13)),
+	}
+	if rng.Intn(10) < 4 {
+		card.Enhancement = BonusCard + Enhancement(rng.Intn(int(LuckyCard)))
+	}
+	switch roll := rng.Intn(100); {
+	case roll < 2:
+		card.Edition = Polychrome
+	case roll < 5:
+		card.Edition = Holographic
+	case roll < 10:
+		card.Edition = Foil
+	}
+	if rng.Intn(5) == 0 {
+		card.Seal = GoldSeal + Seal(rng.Intn(int(PurpleSeal)))
+	}
+	return card
+}
+
+// randomJoker rolls a joker, its edition and, from the Black stake up, the
+// Eternal or Perishable sticker
+func randomJoker(rng *rand.Rand, stake Stake) Joker {
+	joker := Joker{Kind: JokerKinds[rng.Intn(len(JokerKinds))]}
+	roll := rng.Intn(1000)
+	switch {
+	case roll < 3:
+		joker.Edition = Polychrome
+	case roll < 17:
+		joker.Edition = Holographic
+	case roll < 37:
+		joker.Edition = Foil
+	}
+
+	sticker := rng.Intn(10)
+	switch {
+	case stake >= BlackStake && sticker < 3:
+		joker.Eternal = true
+	case stake >= OrangeStake && sticker < 6:
+		joker.Perishable = true
+		joker.RoundsLeft = PerishableRounds
+	}
+	return joker
+}
+
+// Buy pays for a shop item and takes it: jokers and consumables go to their
+// slots and packs are opened for a choice.
+func (g *Game) Buy(idx int) (string, error) {
+	shop := g.Shop
+	if shop == nil {
+		return "", fmt.Errorf("the shop is closed")
+	}
+	if len(shop.Pack) > 0 {
+		return "", fmt.Errorf("choose a card from the open pack first")
+	}
+	if idx < 0 || idx >= len(shop.Items) {
+		return "", fmt.Errorf("there is no item %d", idx+1)
+	}
+	item := shop.Items[idx]
+	if item.Price > g.Money {
+		return "", fmt.Errorf("%s costs $%d, you have $%d", item.describe(), item.Price, g.Money)
+	}
+	if err := g.checkSlots(item); err != nil {
+		return "", err
+	}
+
+	g.Money -= item.Price
+	shop.Items = append(shop.Items[:idx], shop.Items[idx+1:]...)
+	return g.take(item), nil
+}
+
+// ChoosePackItem takes one card from the open booster pack; a negative
+// index skips the pack
+func (g *Game) ChoosePackItem(idx int) (string, error) {
+	shop := g.Shop
+	if shop == nil || len(shop.Pack) == 0 {
+		return "", fmt.Errorf("there is no open pack")
+	}
+	if idx < 0 {
+		shop.Pack = nil
+		return "Skipped the pack", nil
+	}
+	if idx >= len(shop.Pack) {
+		return "", fmt.Errorf("the pack has no card %d", idx+1)
+	}
+	item := shop.Pack[idx]
+	if err := g.checkSlots(item); err != nil {
+		return "", err
+	}
+
+	shop.Pack = nil
+	return g.take(item), nil
+}
+
+func (g *Game) checkSlots(item ShopItem) error {
+	if item.Kind == JokerItem && len(g.Jokers) >= MaxJokers {
+		return fmt.Errorf("all %d joker slots are full", MaxJokers)
+	}
+	if item.Kind == ConsumableItem && len(g.Consumables) >= MaxConsumables {
+		return fmt.Errorf("all %d consumable slots are full", MaxConsumables)
+	}
+	return nil
+}
+
+func (g *Game) take(item ShopItem) string {
+	switch item.Kind {
+	case JokerItem:
+		g.Jokers = append(g.Jokers, item.Joker)
+		return fmt.Sprintf("Added %s", item.Joker)
+	case ConsumableItem:
+		g.gainConsumable(item.Consumable.Kind)
+		return fmt.Sprintf("Added %s", item.Consumable)
+	case PlayingCardItem:
+		card := g.FullDeck.Add(item.Card)
+		return fmt.Sprintf("Added %s to your deck", card)
+	case PackItem:
+		g.Shop.Pack = item.Pack.open(g.rng, g.Config.Stake)
+		return fmt.Sprintf("Opened %s", item.Pack)
+	default:
+		return ""
+	}
+}
+
+func (i ShopItem) describe() string {
+	switch i.Kind {
+	case JokerItem:
+		return i.Joker.String()
+	case ConsumableItem:
+		return i.Consumable.Kind.String()
+	case PlayingCardItem:
+		return i.Card.String()
+	default:
+		return i.Pack.String()
+	}
+}
+
+// Reroll replaces the jokers and consumables for sale. Each reroll in the same
+// shop costs $1 more than the last.
+func (g *Game) Reroll() error {
+	shop := g.Shop
+	if shop == nil {
+		return fmt.Errorf("the shop is closed")
+	}
+	if shop.RerollCost > g.Money {
+		return fmt.Errorf("rerolling costs $%d, you have $%d", shop.RerollCost, g.Money)
+	}
+
+	g.Money -= shop.RerollCost
+	shop.RerollCost++
+	shop.stockCards(g.rng)
+	return nil
+}
+
+// SellJoker removes a joker and pays out its sell value
+func (g *Game) SellJoker(idx int) (int, error) {
+	if idx < 0 || idx >= len(g.Jokers) {
+		return 0, fmt.Errorf("there is no joker %d", idx+1)
+	}
+	if g.Jokers[idx].Eternal {
+		return 0, fmt.Errorf("%s is Eternal and can't be sold", g.Jokers[idx])
+	}
+	value := g.Jokers[idx].GetSellValue()
+	g.Jokers = append(g.Jokers[:idx], g.Jokers[idx+1:]...)
+	g.Money += value
+	return value, nil
+}
@@ -0,0 +1,125 @@
+package balatro
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func newTestGame(seed int64) *Game {
+	return NewGame(RunConfig{Seed: seedFromNumber(uint64(seed))})
+}
+
+func TestInterest(t *testing.T) {
+	tests := []struct {
+		money    int
+		expected int
+	}{
+		{0, 0}, {4, 0}, {5, 1}, {19, 3}, {25, 5}, {100, 5}, {-3, 0},
+	}
+	for _, tt := range tests {
+		if got := interest(tt.money); got != tt.expected {
+			t.Errorf("interest(%d) = %d, want %d", tt.money, got, tt.expected)
+		}
+	}
+}
+
+func TestCashOut(t *testing.T) {
+	g := newTestGame(1)
+	g.Money = 12
+	g.Hands = 2
+	g.Blind = BigBlind
+	g.Jokers = []Joker{{Kind: GoldenJoker}}
+
+	payout := g.CashOut()
+	expected := Payout{Blind: 4, Hands: 2, Interest: 2, Jokers: 4}
+	if payout != expected {
+		t.Errorf("Expected payout %+v, got %+v", expected, payout)
+	}
+	if g.Money != 12+12 {
+		t.Errorf("Expected $24, got $%d", g.Money)
+	}
+	if g.Shop == nil {
+		t.Error("Expected the shop to open after cashing out")
+	}
+}
+
+func TestShopIsSeeded(t *testing.T) {
+	first := newShop(rand.New(rand.NewSource(42)), WhiteStake)
+	second := newShop(rand.New(rand.NewSource(42)), WhiteStake)
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Shops from the same seed differ: %+v vs %+v", first, second)
+	}
+}
+
+func TestRerollCostIncreases(t *testing.T) {
+	g := newTestGame(7)
+	g.Shop = newShop(g.rng, WhiteStake)
+	g.Money = 11
+
+	if err := g.Reroll(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if g.Money != 6 || g.Shop.RerollCost != 6 {
+		t.Errorf("Expected $6 left and a $6 reroll, got $%d and $%d", g.Money, g.Shop.RerollCost)
+	}
+	if err := g.Reroll(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := g.Reroll(); err == nil {
+		t.Error("Expected rerolling without enough money to fail")
+	}
+	if len(g.Shop.Items) != shopCardSlots+shopPackSlots {
+		t.Errorf("Expected %d items after rerolling, got %d", shopCardSlots+shopPackSlots, len(g.Shop.Items))
+	}
+}
+
+func TestBuyAndSellJoker(t *testing.T) {
+	g := newTestGame(3)
+	g.Shop = &Shop{Items: []ShopItem{jokerItem(Joker{Kind: JollyJoker})}, RerollCost: baseRerollCost}
+	g.Money = 2
+
+	if _, err := g.Buy(0); err == nil {
+		t.Error("Expected buying a $3 joker with $2 to fail")
+	}
+	g.Money = 5
+	if _, err := g.Buy(0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(g.Jokers) != 1 || g.Money != 2 || len(g.Shop.Items) != 0 {
+		t.Errorf("Expected 1 joker, $2 and an empty shop, got %v, $%d, %v", g.Jokers, g.Money, g.Shop.Items)
+	}
+
+	value, err := g.SellJoker(0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if value != 1 || g.Money != 3 || len(g.Jokers) != 0 {
+		t.Errorf("Expected to sell for $1, got $%d (money $%d, jokers %v)", value, g.Money, g.Jokers)
+	}
+}
+
+func TestPlanetLevelsUpHand(t *testing.T) {
+	g := newTestGame(5)
+	if _, err := (Consumable{Kind: Mercury}).Use(g, nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	hand := Hand{{Suit: Hearts, Rank: Ace}, {Suit: Spades, Rank: Ace}}
+	eval := EvaluateHandWithContext(hand, ScoringContext{Levels: g.HandLevels})
+	// Level 2 Pair: 15 extra chips and +1 multiplier
+	if eval.CardValue != 37 || eval.Multiplier != 3 || eval.TotalScore != 111 {
+		t.Errorf("Expected 37 chips x3 = 111, got %d x%d = %d", eval.CardValue, eval.Multiplier, eval.TotalScore)
+	}
+}
+
+func TestJokerEffects(t *testing.T) {
+	hand := Hand{{Suit: Hearts, Rank: King}, {Suit: Hearts, Rank: King}}
+	jokers := []Joker{{Kind: JollyJoker}, {Kind: ScaryFace}, {Kind: Cavendish}}
+	eval := EvaluateHandWithContext(hand, ScoringContext{Jokers: jokers})
+
+	// 20 chips + 60 from Scary Face, (2 + 8 from Jolly) x3 from Cavendish
+	if eval.CardValue != 80 || eval.Mult != 30 || eval.TotalScore != 2400 {
+		t.Errorf("Expected 80 chips x30 = 2400, got %d x%.1f = %d", eval.CardValue, eval.Mult, eval.TotalScore)
+	}
+}
@@ -0,0 +1,144 @@
+package balatro
+
+import (
+	"fmt"
+	"io"
+)
+
+// maxRunActions stops a run whose strategy never finishes a blind
+const maxRunActions = 10000
+
+// PlayRun plays a whole run with the strategy, shopping with a simple
+// policy between blinds. It returns the highest ante whose boss was beaten.
+func PlayRun(g *Game, strategy Strategy) int {
+	cleared := 0
+	for actions := 0; !g.Over && actions < maxRunActions; actions++ {
+		if g.Shop != nil {
+			autoShop(g)
+			continue
+		}
+
+		action := strategy.Choose(g)
+		var err error
+		if action.Discard {
+			_, err = g.Discard(action.Indices)
+		} else {
+			_, _, err = g.PlayHand(action.Indices)
+		}
+		if err != nil {
+			// A broken strategy shouldn't stall the run
+			if _, _, err := g.PlayHand([]int{0}); err != nil {
+				g.Over = true
+			}
+		}
+
+		if g.BlindCleared() {
+			if g.Blind == BossBlind {
+				cleared = g.Ante
+			}
+			g.CashOut()
+		} else if g.BlindLost() {
+			g.Over = true
+		}
+	}
+	g.Over = true
+	return cleared
+}
+
+// autoShop buys every joker and planet it can afford, uses the planets right
+// away, takes the first usable card from open packs and leaves for the next blind
+func autoShop(g *Game) {
+	for len(g.Shop.Pack) > 0 {
+		if _, err := g.ChoosePackItem(0); err != nil {
+			g.ChoosePackItem(-1)
+		}
+	}
+
+	for bought := true; bought; {
+		bought = false
+		for idx, item := range g.Shop.Items {
+			wanted := item.Kind == JokerItem ||
+				(item.Kind == ConsumableItem && item.Consumable.Kind.Category() == PlanetCategory)
+			if !wanted {
+				continue
+			}
+			if _, err := g.Buy(idx); err == nil {
+				bought = true
+				break
+			}
+		}
+		for idx := 0; idx < len(g.Consumables); {
+			if g.Consumables[idx].Kind.Category() != PlanetCategory {
+				idx++
+				continue
+			}
+			if _, err := g.UseConsumable(idx, nil); err != nil {
+				idx++
+			}
+		}
+	}
+	g.NextBlind()
+}
+
+// Simulation plays many seeded runs with the same strategy
+type Simulation struct {
+	Strategy Strategy
+	// Config is the deck and stake for every run. Each run's seed is drawn
+	// from Config.Seed, or from a random seed if it is empty.
+	Config RunConfig
+	Runs   int
+}
+
+// SimulationResult counts how far the simulated runs got
+type SimulationResult struct {
+	Strategy string
+	Seed     string
+	Runs     int
+	Wins     int
+	// Cleared counts the runs that beat each ante's boss blind; index 0 is ante 1
+	Cleared [WinningAnte]int
+}
+
+// Run plays every run and collects the results
+func (s Simulation) Run() SimulationResult {
+	seed := s.Config.Seed
+	if seed == "" {
+		seed = RandomSeed()
+	}
+	seeds, _ := newRand(seedNumber(seed))
+	result := SimulationResult{Strategy: s.Strategy.Name(), Seed: seed, Runs: s.Runs}
+	for i := 0; i < s.Runs; i++ {
+		config := s.Config
+		config.Seed = seedFromNumber(seeds.Uint64())
+		g := NewGame(config)
+		cleared := PlayRun(g, s.Strategy)
+		for ante := 1; ante <= cleared && ante <= WinningAnte; ante++ {
+			result.Cleared[ante-1]++
+		}
+		if g.Won {
+			result.Wins++
+		}
+	}
+	return result
+}
+
+// WinRate is the share of runs that beat the final boss
+func (r SimulationResult) WinRate() float64 {
+	if r.Runs == 0 {
+		return 0
+	}
+	return float64(r.Wins) / float64(r.Runs)
+}
+
+// Report writes the share of runs that cleared each ante
+func (r SimulationResult) Report(w io.Writer) {
+	fmt.Fprintf(w, "Strategy: %s, %d runs from seed %s\n", r.Strategy, r.Runs, r.Seed)
+	for ante, count := range r.Cleared {
+		rate := 0.0
+		if r.Runs > 0 {
+			rate = float64(count) / float64(r.Runs)
+		}
+		fmt.Fprintf(w, "Ante %d cleared: %5.1f%% (%d)\n", ante+1, rate*100, count)
+	}
+	fmt.Fprintf(w, "Win rate: %.1f%% (%d)\n", r.WinRate()*100, r.Wins)
+}
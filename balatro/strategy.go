@@ -0,0 +1,253 @@
+package balatro
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+)
+
+// Action is a strategy's decision for the current hand: play or discard the
+// cards at Indices
+type Action struct {
+	Discard bool
+	Indices []int
+}
+
+// Strategy chooses what to do with the cards in hand during a blind. Like
+// the player, a strategy can't see cards dealt face down.
+type Strategy interface {
+	Name() string
+	Choose(g *Game) Action
+}
+
+// ParseStrategy looks up a built in strategy by name, ignoring case. seed
+// drives strategies that sample random draws.
+func ParseStrategy(name string, seed int64) (Strategy, error) {
+	switch strings.ToLower(name) {
+	case "greedy":
+		return GreedyStrategy{}, nil
+	case "lookahead":
+		return NewLookaheadStrategy(seed), nil
+	default:
+		return nil, fmt.Errorf("unknown strategy %q", name)
+	}
+}
+
+// GreedyStrategy always plays the highest scoring subset of the hand
+type GreedyStrategy struct{}
+
+func (GreedyStrategy) Name() string {
+	return "greedy"
+}
+
+func (GreedyStrategy) Choose(g *Game) Action {
+	return Action{Indices: visiblePlay(g.PlayerHand, g.ScoringContext(nil))}
+}
+
+// visiblePlay is the best play from the cards that can be seen, or a blind
+// card when every card is face down
+func visiblePlay(hand Hand, ctx ScoringContext) []int {
+	visible, positions := visibleCards(hand)
+	if len(visible) == 0 {
+		return []int{0}
+	}
+	indices, _ := bestPlay(visible, ctx)
+	toPositions(indices, positions)
+	return indices
+}
+
+// LookaheadStrategy plays the best hand when it clears the blind, and
+// otherwise discards when redrawing from the remaining deck is expected to
+// score more than playing now
+type LookaheadStrategy struct {
+	// Samples is how many redraws are tried for each candidate discard
+	Samples int
+	// Candidates is how many of the best scoring subsets are tried as the cards to keep
+	Candidates int
+
+	rng *rand.Rand
+}
+
+func NewLookaheadStrategy(seed int64) *LookaheadStrategy {
+	return &LookaheadStrategy{
+		Samples:    20,
+		Candidates: 4,
+		rng:        rand.New(rand.NewSource(seed)),
+	}
+}
+
+func (s *LookaheadStrategy) Name() string {
+	return "lookahead"
+}
+
+func (s *LookaheadStrategy) Choose(g *Game) Action {
+	ctx := g.ScoringContext(nil)
+	visible, positions := visibleCards(g.PlayerHand)
+	if len(visible) == 0 {
+		return Action{Indices: []int{0}}
+	}
+	indices, best := bestPlay(visible, ctx)
+	toPositions(indices, positions)
+	play := Action{Indices: indices}
+	if g.Discards <= 0 || len(g.Deck.Cards) == 0 || g.Score+best.TotalScore >= g.Target() {
+		return play
+	}
+
+	bestValue := float64(best.TotalScore)
+	var bestDiscard []int
+	for _, discard := range s.candidateDiscards(visible, ctx) {
+		toPositions(discard, positions)
+		if value := s.discardValue(g.PlayerHand, discard, g.Deck.Cards, ctx); value > bestValue {
+			bestValue = value
+			bestDiscard = discard
+		}
+	}
+	if bestDiscard == nil {
+		return play
+	}
+	return Action{Discard: true, Indices: bestDiscard}
+}
+
+// candidateDiscards builds discard sets that keep one of the best scoring
+// subsets, or every card of the most common suit to chase a flush
+func (s *LookaheadStrategy) candidateDiscards(hand Hand, ctx ScoringContext) [][]int {
+	plays := BestHands(hand, ctx, s.Candidates)
+	keeps := make([][]int, 0, s.Candidates+1)
+	for _, play := range plays {
+		keeps = append(keeps, play.Indices)
+	}
+
+	suitCounts := map[Suit][]int{}
+	for idx, card := range hand {
+		if card.HasRank() {
+			suitCounts[card.Suit] = append(suitCounts[card.Suit], idx)
+		}
+	}
+	var flushDraw []int
+	for suit := Hearts; suit <= Spades; suit++ {
+		if len(suitCounts[suit]) > len(flushDraw) {
+			flushDraw = suitCounts[suit]
+		}
+	}
+	if len(flushDraw) >= 3 && len(flushDraw) < 5 {
+		keeps = append(keeps, flushDraw)
+	}
+
+	discards := make([][]int, 0, len(keeps))
+	for _, keep := range keeps {
+		kept := make(map[int]bool, len(keep))
+		for _, idx := range keep {
+			kept[idx] = true
+		}
+		discard := make([]int, 0, len(hand))
+		for idx := range hand {
+			if !kept[idx] {
+				discard = append(discard, idx)
+			}
+		}
+		// Only MaxSelection cards can be discarded, so throw away the lowest
+		sort.SliceStable(discard, func(a, b int) bool {
+			return hand[discard[a]].GetValue() < hand[discard[b]].GetValue()
+		})
+		if len(discard) > MaxSelection {
+			discard = discard[:MaxSelection]
+		}
+		if len(discard) > 0 {
+			discards = append(discards, discard)
+		}
+	}
+	return discards
+}
+
+// discardValue estimates the best score available after discarding the
+// given cards and drawing replacements from the remaining deck. Face down
+// cards that are kept could be any card not yet seen, so they are sampled
+// along with the draws.
+func (s *LookaheadStrategy) discardValue(hand Hand, discard []int, remaining []Card, ctx ScoringContext) float64 {
+	dropped := make(map[int]bool, len(discard))
+	for _, idx := range discard {
+		dropped[idx] = true
+	}
+	kept := make(Hand, 0, len(hand))
+	pool := append([]Card{}, remaining...)
+	hidden := 0
+	for idx, card := range hand {
+		switch {
+		case card.FaceDown:
+			pool = append(pool, card)
+			if !dropped[idx] {
+				hidden++
+			}
+		case !dropped[idx]:
+			kept = append(kept, card)
+		}
+	}
+	draws := len(discard)
+	if draws > len(remaining) {
+		draws = len(remaining)
+	}
+	unknown := hidden + draws
+	// the hands after the discard are played with one discard fewer
+	ctx.Discards--
+
+	total := 0
+	for i := 0; i < s.Samples; i++ {
+		// A partial shuffle is enough to pick the unknown cards
+		for j := 0; j < unknown; j++ {
+			k := j + s.rng.Intn(len(pool)-j)
+			pool[j], pool[k] = pool[k], pool[j]
+		}
+		next := append(append(Hand{}, kept...), pool[:unknown]...)
+		_, evaluation := bestPlay(next, ctx)
+		total += evaluation.TotalScore
+	}
+	return float64(total) / float64(s.Samples)
+}
+
+// forEachPlay scores every subset of 1 to MaxSelection cards. indices is
+// reused between calls, so copy it to keep it. Random effects like Lucky
+// cards are left out so the scores are stable.
+func forEachPlay(hand Hand, ctx ScoringContext, visit func(indices []int, evaluation HandEvaluation)) {
+	ctx.Rand = nil
+	selected := make(Hand, 0, MaxSelection)
+	held := make(Hand, 0, len(hand))
+	indices := make([]int, 0, MaxSelection)
+	for mask := 1; mask < 1<<len(hand); mask++ {
+		if bitCount(mask) > MaxSelection {
+			continue
+		}
+		selected, held, indices = selected[:0], held[:0], indices[:0]
+		for idx, card := range hand {
+			if mask&(1<<idx) != 0 {
+				selected = append(selected, card)
+				indices = append(indices, idx)
+			} else {
+				held = append(held, card)
+			}
+		}
+		ctx.Held = held
+		visit(indices, EvaluateHandWithContext(selected, ctx))
+	}
+}
+
+// bestPlay returns the highest scoring subset of the hand
+func bestPlay(hand Hand, ctx ScoringContext) ([]int, HandEvaluation) {
+	var best []int
+	var bestEvaluation HandEvaluation
+	forEachPlay(hand, ctx, func(indices []int, evaluation HandEvaluation) {
+		if best == nil || evaluation.TotalScore > bestEvaluation.TotalScore {
+			best = append(best[:0], indices...)
+			bestEvaluation = evaluation
+		}
+	})
+	return best, bestEvaluation
+}
+
+func bitCount(n int) int {
+	count := 0
+	for ; n != 0; n &= n - 1 {
+		count++
+	}
+	return count
+}
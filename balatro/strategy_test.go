@@ -0,0 +1,143 @@
+package balatro
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGreedyPlaysBestSubset(t *testing.T) {
+	g := newTestGame(31)
+	g.PlayerHand = []Card{
+		{Suit: Hearts, Rank: Two},
+		{Suit: Spades, Rank: Nine},
+		{Suit: Hearts, Rank: King},
+		{Suit: Clubs, Rank: Nine},
+		{Suit: Diamonds, Rank: Four},
+		{Suit: Diamonds, Rank: Nine},
+		{Suit: Hearts, Rank: Nine},
+		{Suit: Clubs, Rank: Three},
+	}
+
+	action := GreedyStrategy{}.Choose(g)
+	if action.Discard {
+		t.Fatal("Expected the greedy strategy to play")
+	}
+	if handType := determineHandType(g.selectedHand(action.Indices)); handType != FourOfAKind {
+		t.Errorf("Expected Four of a Kind, got %s from %v", handType, action.Indices)
+	}
+}
+
+func TestLookaheadDiscardsForFlush(t *testing.T) {
+	g := newTestGame(32)
+	g.PlayerHand = []Card{
+		{Suit: Hearts, Rank: Two},
+		{Suit: Hearts, Rank: Five},
+		{Suit: Hearts, Rank: Seven},
+		{Suit: Hearts, Rank: Jack},
+		{Suit: Spades, Rank: Three},
+		{Suit: Clubs, Rank: Eight},
+		{Suit: Diamonds, Rank: Ten},
+		{Suit: Spades, Rank: Four},
+	}
+	g.Deck = &Deck{}
+	for rank := Two; rank <= Ace; rank++ {
+		g.Deck.Cards = append(g.Deck.Cards, Card{Suit: Hearts, Rank: rank})
+	}
+
+	action := NewLookaheadStrategy(1).Choose(g)
+	if !action.Discard {
+		t.Fatalf("Expected a discard when every draw makes a flush, got %+v", action)
+	}
+	for _, idx := range action.Indices {
+		if g.PlayerHand[idx].Suit == Hearts {
+			t.Errorf("Expected to keep the hearts, discarded %s", g.PlayerHand[idx])
+		}
+	}
+
+	// A hand that already beats the blind is played
+	g.Score = g.Target()
+	if action := NewLookaheadStrategy(1).Choose(g); action.Discard {
+		t.Errorf("Expected to play once the blind is beaten, got %+v", action)
+	}
+}
+
+func TestStrategiesCantSeeFaceDownCards(t *testing.T) {
+	g := newTestGame(33)
+	g.PlayerHand = []Card{
+		{Suit: Hearts, Rank: Ace, FaceDown: true},
+		{Suit: Spades, Rank: Ace, FaceDown: true},
+		{Suit: Clubs, Rank: Ace, FaceDown: true},
+		{Suit: Clubs, Rank: Three},
+		{Suit: Diamonds, Rank: Three},
+		{Suit: Clubs, Rank: Seven},
+		{Suit: Spades, Rank: Nine},
+		{Suit: Hearts, Rank: Jack},
+	}
+
+	for _, strategy := range []Strategy{GreedyStrategy{}, NewLookaheadStrategy(1)} {
+		action := strategy.Choose(g)
+		for _, idx := range action.Indices {
+			if g.PlayerHand[idx].FaceDown {
+				t.Errorf("Expected %s to leave the face down cards alone, got %+v", strategy.Name(), action)
+			}
+		}
+	}
+
+	g.PlayerHand = []Card{{Suit: Hearts, Rank: Ace, FaceDown: true}, {Suit: Spades, Rank: Two, FaceDown: true}}
+	if action := (GreedyStrategy{}).Choose(g); len(action.Indices) != 1 {
+		t.Errorf("Expected a blind card when nothing can be seen, got %+v", action)
+	}
+}
+
+func TestLookaheadCountsTheDiscard(t *testing.T) {
+	g := newTestGame(34)
+	g.PlayerHand = []Card{
+		{Suit: Hearts, Rank: King},
+		{Suit: Spades, Rank: King},
+		{Suit: Clubs, Rank: Two},
+	}
+	g.Jokers = []Joker{{Kind: Banner}}
+	g.Discards = 3
+	ctx := g.ScoringContext(nil)
+
+	// drawing the same card back leaves the hand as it was, but with one
+	// discard fewer for Banner to count
+	s := NewLookaheadStrategy(1)
+	value := s.discardValue(g.PlayerHand, []int{2}, []Card{{Suit: Clubs, Rank: Two}}, ctx)
+	ctx.Discards = 2
+	_, after := bestPlay(g.PlayerHand, ctx)
+	if value != float64(after.TotalScore) {
+		t.Errorf("Expected the discard to be worth %d with 2 discards left, got %.0f", after.TotalScore, value)
+	}
+}
+
+func TestSimulationIsSeeded(t *testing.T) {
+	simulation := Simulation{Strategy: GreedyStrategy{}, Config: RunConfig{Seed: "SEED5"}, Runs: 4}
+	first := simulation.Run()
+	second := simulation.Run()
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected the same results from the same seeds, got %+v and %+v", first, second)
+	}
+	if first.Runs != 4 || first.Cleared[0] == 0 {
+		t.Errorf("Expected greedy play to clear ante 1 sometimes, got %+v", first)
+	}
+	for ante := 1; ante < WinningAnte; ante++ {
+		if first.Cleared[ante] > first.Cleared[ante-1] {
+			t.Errorf("Expected fewer runs to clear ante %d than ante %d, got %v", ante+1, ante, first.Cleared)
+		}
+	}
+	if first.Wins > first.Cleared[WinningAnte-1] {
+		t.Errorf("Expected every win to clear the final ante, got %+v", first)
+	}
+}
+
+func TestParseStrategy(t *testing.T) {
+	for _, name := range []string{"greedy", "Lookahead"} {
+		if _, err := ParseStrategy(name, 1); err != nil {
+			t.Errorf("Unexpected error for %q: %v", name, err)
+		}
+	}
+	if _, err := ParseStrategy("random", 1); err == nil {
+		t.Error("Expected an unknown strategy to fail")
+	}
+}
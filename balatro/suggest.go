@@ -0,0 +1,73 @@
+package balatro
+
+import (
+	"fmt"
+	"sort"
+)
+
+// HandOption is one way to play cards from a hand and how it would score
+type HandOption struct {
+	// Indices are the positions of the played cards in the hand
+	Indices    []int
+	Cards      Hand
+	Evaluation HandEvaluation
+}
+
+func (o HandOption) String() string {
+	e := o.Evaluation
+	return fmt.Sprintf("%s %s: %d chips x %.1f mult = %d", e.Type, o.Cards, e.CardValue, e.Mult, e.TotalScore)
+}
+
+// BestHands scores every subset of 1 to MaxSelection cards from the hand,
+// holding the rest, and returns the n best. Random effects like Lucky cards
+// are left out so the ranking is stable.
+func BestHands(hand Hand, ctx ScoringContext, n int) []HandOption {
+	options := make([]HandOption, 0, 256)
+	forEachPlay(hand, ctx, func(indices []int, evaluation HandEvaluation) {
+		option := HandOption{Indices: append([]int(nil), indices...), Evaluation: evaluation}
+		for _, idx := range indices {
+			option.Cards = append(option.Cards, hand[idx])
+		}
+		options = append(options, option)
+	})
+	sort.SliceStable(options, func(a, b int) bool {
+		return options[a].Evaluation.TotalScore > options[b].Evaluation.TotalScore
+	})
+	if n >= 0 && n < len(options) {
+		options = options[:n]
+	}
+	return options
+}
+
+// SuggestHands returns the n best plays from the cards in hand. Face down
+// cards are left out since the player can't see them.
+func (g *Game) SuggestHands(n int) []HandOption {
+	visible, positions := visibleCards(g.PlayerHand)
+	options := BestHands(visible, g.ScoringContext(nil), n)
+	for _, option := range options {
+		toPositions(option.Indices, positions)
+	}
+	return options
+}
+
+// visibleCards returns the cards in hand that aren't face down, along with
+// their positions in the hand
+func visibleCards(hand Hand) (Hand, []int) {
+	visible := make(Hand, 0, len(hand))
+	positions := make([]int, 0, len(hand))
+	for idx, card := range hand {
+		if !card.FaceDown {
+			visible = append(visible, card)
+			positions = append(positions, idx)
+		}
+	}
+	return visible, positions
+}
+
+// toPositions turns indexes into the visible cards back into positions in
+// the hand, in place
+func toPositions(indices []int, positions []int) {
+	for i, idx := range indices {
+		indices[i] = positions[idx]
+	}
+}
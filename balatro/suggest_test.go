@@ -0,0 +1,62 @@
+package balatro
+
+import (
+	"testing"
+)
+
+func TestBestHands(t *testing.T) {
+	hand := Hand{
+		{Suit: Hearts, Rank: Two},
+		{Suit: Hearts, Rank: Six},
+		{Suit: Hearts, Rank: King},
+		{Suit: Spades, Rank: King},
+		{Suit: Hearts, Rank: Nine},
+		{Suit: Hearts, Rank: Four},
+		{Suit: Clubs, Rank: Three},
+		{Suit: Diamonds, Rank: Ace},
+	}
+
+	options := BestHands(hand, ScoringContext{}, 5)
+	if len(options) != 5 {
+		t.Fatalf("Expected 5 options, got %d", len(options))
+	}
+	if options[0].Evaluation.Type != Flush {
+		t.Errorf("Expected a Flush first, got %s", options[0])
+	}
+	for i := 1; i < len(options); i++ {
+		if options[i].Evaluation.TotalScore > options[i-1].Evaluation.TotalScore {
+			t.Errorf("Expected options best first, got %s before %s", options[i-1], options[i])
+		}
+	}
+	for i, idx := range options[0].Indices {
+		if hand[idx] != options[0].Cards[i] {
+			t.Errorf("Expected index %d to match %s", idx, options[0].Cards[i])
+		}
+	}
+
+	// Every subset of 1 to 5 cards from 8
+	if all := BestHands(hand, ScoringContext{}, -1); len(all) != 8+28+56+70+56 {
+		t.Errorf("Expected 218 subsets, got %d", len(all))
+	}
+}
+
+func TestSuggestHandsSkipsFaceDown(t *testing.T) {
+	g := newTestGame(35)
+	g.PlayerHand = []Card{
+		{Suit: Hearts, Rank: Ace, FaceDown: true},
+		{Suit: Spades, Rank: Ace, FaceDown: true},
+		{Suit: Clubs, Rank: Three},
+		{Suit: Diamonds, Rank: Three},
+		{Suit: Clubs, Rank: Seven},
+	}
+
+	options := g.SuggestHands(1)
+	if len(options) != 1 || options[0].Evaluation.Type != Pair {
+		t.Fatalf("Expected the pair of threes, got %v", options)
+	}
+	for _, idx := range options[0].Indices {
+		if g.PlayerHand[idx].FaceDown {
+			t.Errorf("Expected the face down cards to be left out, got %v", options[0].Indices)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+//go:build !linux && !darwin
+
+package balatro
+
+import (
+	"errors"
+)
+
+type termState struct{}
+
+// isTerminal always reports false, so the CLI falls back to line prompts
+// on platforms without raw mode support
+func isTerminal(fd uintptr) bool {
+	return false
+}
+
+func makeRaw(fd uintptr) (*termState, error) {
+	return nil, errors.New("raw terminal input is not supported on this platform")
+}
+
+func restoreTerminal(fd uintptr, state *termState) error {
+	return nil
+}
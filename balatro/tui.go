@@ -0,0 +1,481 @@
+package balatro
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiDim       = "\x1b[2m"
+	ansiUnderline = "\x1b[4m"
+	ansiReverse   = "\x1b[7m"
+	ansiRed       = "\x1b[31m"
+	ansiClear     = "\x1b[H\x1b[2J"
+
+	// tallyFrames is how many steps the score tally counts up in
+	tallyFrames = 12
+	tallyDelay  = 40 * time.Millisecond
+)
+
+const tuiHelp = "←/→ move  space select  p play  d discard  r/s sort rank/suit  a suggest  o odds  v deck  1-9 use consumable  q quit"
+
+// key is a key press read in raw mode
+type key int
+
+const (
+	keyRune key = iota
+	keyLeft
+	keyRight
+	keyEnter
+	keySpace
+	keyEscape
+	keyInterrupt
+)
+
+// readKey reads one key press. Arrow keys arrive as escape sequences, which
+// are in the buffer together with their escape byte.
+func readKey(r *bufio.Reader) (key, byte, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return keyRune, 0, err
+	}
+	switch c {
+	case 27:
+		if r.Buffered() < 2 {
+			return keyEscape, 0, nil
+		}
+		if next, _ := r.Peek(2); next[0] != '[' {
+			return keyEscape, 0, nil
+		}
+		r.ReadByte()
+		code, _ := r.ReadByte()
+		switch code {
+		case 'C':
+			return keyRight, 0, nil
+		case 'D':
+			return keyLeft, 0, nil
+		default:
+			return keyEscape, 0, nil
+		}
+	case '\r', '\n':
+		return keyEnter, 0, nil
+	case ' ':
+		return keySpace, 0, nil
+	case 3, 4:
+		return keyInterrupt, 0, nil
+	default:
+		return keyRune, c, nil
+	}
+}
+
+// tui is the state of the full screen interface during a blind
+type tui struct {
+	g      *Game
+	out    *bufio.Writer
+	cursor int
+	// selected holds the IDs of the selected cards, so sorting keeps the selection
+	selected map[int]bool
+	message  string
+	panel    string
+	tally    string
+	// confirmDiscard is set once the odds of the selected discard are on screen
+	confirmDiscard bool
+}
+
+// PlayTUI runs the game full screen, reading single key presses. The shop
+// between blinds uses the line prompts. It returns an error if the terminal
+// can't be switched to raw mode.
+func (g *Game) PlayTUI() error {
+	state, err := makeRaw(os.Stdin.Fd())
+	if err != nil {
+		return err
+	}
+	t := &tui{g: g, out: bufio.NewWriter(os.Stdout), selected: map[int]bool{}}
+	t.enterScreen()
+	reader := bufio.NewReader(os.Stdin)
+
+	quit := false
+	for !g.Over && !quit {
+		g.autosave()
+		if g.Shop != nil {
+			// The shop is a line prompt, so give the terminal back for it
+			t.leaveScreen()
+			restoreTerminal(os.Stdin.Fd(), state)
+			fmt.Print(t.message)
+			t.message = ""
+			if !g.shopPhase(reader) {
+				quit = true
+				break
+			}
+			if state, err = makeRaw(os.Stdin.Fd()); err != nil {
+				fmt.Println("Falling back to prompts:", err)
+				g.PlayPrompt()
+				return nil
+			}
+			t.enterScreen()
+			continue
+		}
+
+		t.draw()
+		k, c, err := readKey(reader)
+		if err != nil {
+			break
+		}
+		quit = t.handle(k, c)
+	}
+
+	if g.Shop == nil {
+		t.leaveScreen()
+		restoreTerminal(os.Stdin.Fd(), state)
+		fmt.Print(t.message)
+	}
+	g.finish()
+	return nil
+}
+
+func (t *tui) enterScreen() {
+	// Switch to the alternate screen and hide the cursor
+	t.out.WriteString("\x1b[?1049h\x1b[?25l")
+	t.out.Flush()
+}
+
+func (t *tui) leaveScreen() {
+	t.out.WriteString("\x1b[?25h\x1b[?1049l")
+	t.out.Flush()
+}
+
+// handle acts on a key press. It returns true when the player quits.
+func (t *tui) handle(k key, c byte) bool {
+	g := t.g
+	pendingDiscard := t.confirmDiscard
+	t.confirmDiscard = false
+	t.tally = ""
+	t.message = ""
+	if k != keyRune || c != 'o' && c != 'v' && c != 'd' {
+		t.panel = ""
+	}
+
+	switch k {
+	case keyLeft:
+		if t.cursor > 0 {
+			t.cursor--
+		}
+	case keyRight:
+		if t.cursor < len(g.PlayerHand)-1 {
+			t.cursor++
+		}
+	case keySpace, keyEnter:
+		t.toggle()
+	case keyEscape:
+		t.selected = map[int]bool{}
+	case keyInterrupt:
+		return true
+	case keyRune:
+		switch c {
+		case 'h':
+			if t.cursor > 0 {
+				t.cursor--
+			}
+		case 'l':
+			if t.cursor < len(g.PlayerHand)-1 {
+				t.cursor++
+			}
+		case 'q':
+			return true
+		case 'p':
+			t.play()
+		case 'd':
+			t.discard(pendingDiscard)
+		case 'r', 's':
+			g.SortHand(c == 's')
+		case 'a':
+			t.suggest()
+		case 'o':
+			indices := t.selectedIndices()
+			if len(indices) == 0 {
+				t.message = "Select cards to see the odds of discarding them"
+				break
+			}
+			t.panel = g.DiscardOdds(indices).String()
+		case 'v':
+			t.panel = g.DeckView()
+		default:
+			if c >= '1' && c <= '9' {
+				t.use(int(c - '1'))
+			}
+		}
+	}
+	return false
+}
+
+func (t *tui) toggle() {
+	if t.cursor >= len(t.g.PlayerHand) {
+		return
+	}
+	id := t.g.PlayerHand[t.cursor].ID
+	switch {
+	case t.selected[id]:
+		delete(t.selected, id)
+	case len(t.selected) >= MaxSelection:
+		t.message = fmt.Sprintf("You can select up to %d cards", MaxSelection)
+	default:
+		t.selected[id] = true
+	}
+}
+
+// selectedIndices returns the positions of the selected cards in hand
+func (t *tui) selectedIndices() []int {
+	indices := make([]int, 0, len(t.selected))
+	for idx, card := range t.g.PlayerHand {
+		if t.selected[card.ID] {
+			indices = append(indices, idx)
+		}
+	}
+	return indices
+}
+
+// resetHand clears the selection once the cards in hand have changed
+func (t *tui) resetHand() {
+	t.selected = map[int]bool{}
+	if t.cursor >= len(t.g.PlayerHand) {
+		t.cursor = len(t.g.PlayerHand) - 1
+	}
+	if t.cursor < 0 {
+		t.cursor = 0
+	}
+}
+
+func (t *tui) play() {
+	g := t.g
+	indices := t.selectedIndices()
+	if len(indices) == 0 {
+		t.message = "Select cards to play first"
+		return
+	}
+	selected, evaluation, err := g.PlayHand(indices)
+	if err != nil {
+		t.message = err.Error()
+		return
+	}
+	t.resetHand()
+	t.animateTally(selected, evaluation)
+
+	switch {
+	case g.BlindCleared():
+		blind := g.Blind
+		payout := g.CashOut()
+		t.message = fmt.Sprintf("%s cleared! Earned $%d (blind $%d, hands $%d, interest $%d, jokers $%d, gold cards $%d)\n\n",
+			blind, payout.Total(), payout.Blind, payout.Hands, payout.Interest, payout.Jokers, payout.Held)
+	case g.BlindLost():
+		t.message = "Out of hands! Game Over!\n"
+		g.Over = true
+	}
+}
+
+// animateTally counts the chips up to their total, then shows the score
+func (t *tui) animateTally(selected Hand, evaluation HandEvaluation) {
+	prefix := fmt.Sprintf("%s %s: ", evaluation.Type, selected)
+	if evaluation.NotAllowed {
+		t.tally = prefix + fmt.Sprintf("%s does not allow this hand, it scores nothing!", t.g.ActiveBoss())
+		t.draw()
+		return
+	}
+	for frame := 1; frame <= tallyFrames; frame++ {
+		chips := evaluation.CardValue * frame / tallyFrames
+		t.tally = prefix + fmt.Sprintf("%s%d chips%s x %.1f mult", ansiBold, chips, ansiReset, evaluation.Mult)
+		t.draw()
+		time.Sleep(tallyDelay)
+	}
+	t.tally = prefix + fmt.Sprintf("%d chips x %.1f mult = %s%d%s", evaluation.CardValue, evaluation.Mult, ansiBold, evaluation.TotalScore, ansiReset)
+	var notes []string
+	for _, idx := range evaluation.Shattered {
+		notes = append(notes, fmt.Sprintf("%s shattered!", selected[idx]))
+	}
+	if evaluation.Dollars > 0 {
+		notes = append(notes, fmt.Sprintf("Earned $%d", evaluation.Dollars))
+	}
+	t.message = strings.Join(notes, " ")
+}
+
+// discard shows the odds of the selected discard, and discards once the
+// player presses d again
+func (t *tui) discard(confirmed bool) {
+	g := t.g
+	indices := t.selectedIndices()
+	if len(indices) == 0 {
+		t.message = "Select cards to discard first"
+		return
+	}
+	if g.Discards <= 0 {
+		t.message = "No discards left"
+		return
+	}
+	if !confirmed {
+		t.panel = g.DiscardOdds(indices).String()
+		t.message = "Press d again to discard these cards"
+		t.confirmDiscard = true
+		return
+	}
+	t.panel = ""
+	discarded, err := g.Discard(indices)
+	if err != nil {
+		t.message = err.Error()
+		return
+	}
+	t.resetHand()
+	t.message = fmt.Sprintf("Discarded %s", discarded)
+}
+
+// suggest selects the best scoring cards
+func (t *tui) suggest() {
+	options := t.g.SuggestHands(1)
+	if len(options) == 0 {
+		return
+	}
+	t.selected = map[int]bool{}
+	for _, idx := range options[0].Indices {
+		t.selected[t.g.PlayerHand[idx].ID] = true
+	}
+	t.message = "Suggested: " + options[0].String()
+}
+
+func (t *tui) use(idx int) {
+	message, err := t.g.UseConsumable(idx, t.selectedIndices())
+	if err != nil {
+		t.message = err.Error()
+		return
+	}
+	t.resetHand()
+	t.message = message
+}
+
+// cardLabel is a card's short form for the hand row. A * marks cards with
+// an enhancement, edition or seal, which the detail line spells out.
+func cardLabel(card Card) string {
+	switch {
+	case card.FaceDown:
+		return "??"
+	case card.Enhancement == StoneCard:
+		return "Stone"
+	}
+	label := card.Rank.String() + card.Suit.String()
+	if card.Enhancement != NoEnhancement || card.Edition != NoEdition || card.Seal != NoSeal {
+		label += "*"
+	}
+	return label
+}
+
+// draw renders the whole screen
+func (t *tui) draw() {
+	g := t.g
+	var b strings.Builder
+	b.WriteString(ansiClear)
+
+	fmt.Fprintf(&b, "%s Balatro - Round %d: Ante %d, %s %s  %sSeed: %s%s\n", ansiBold, g.Round, g.Ante, g.Blind, ansiReset, ansiDim, g.Seed, ansiReset)
+	fmt.Fprintf(&b, " Score: %s%d%s / %d\n", ansiBold, g.Score, ansiReset, g.Target())
+	fmt.Fprintf(&b, " Hands: %d  Discards: %d  Money: $%d\n", g.Hands, g.Discards, g.Money)
+	if boss := g.ActiveBoss(); boss != NoBoss {
+		fmt.Fprintf(&b, " Boss: %s - %s\n", boss, boss.Description())
+	} else {
+		fmt.Fprintf(&b, " Upcoming boss: %s - %s\n", g.Boss, g.Boss.Description())
+	}
+
+	b.WriteString("\n Jokers: ")
+	if len(g.Jokers) == 0 {
+		b.WriteString("none")
+	}
+	b.WriteString(jokerList(g.Jokers))
+	b.WriteString("\n Consumables: ")
+	if len(g.Consumables) == 0 {
+		b.WriteString("none")
+	}
+	for i, consumable := range g.Consumables {
+		fmt.Fprintf(&b, "%d: %s  ", i+1, consumable)
+	}
+	b.WriteString("\n\n ")
+
+	// The hand, with the cursor marked on the line below
+	cursorColumn, cursorWidth := 0, 0
+	column := 1
+	for idx, card := range g.PlayerHand {
+		label := cardLabel(card)
+		style := ""
+		if !card.FaceDown && (card.Suit == Hearts || card.Suit == Diamonds) && card.Enhancement != StoneCard {
+			style += ansiRed
+		}
+		if !card.FaceDown && g.ActiveBoss().Debuffs(card) {
+			style += ansiDim
+		}
+		if t.selected[card.ID] {
+			style += ansiReverse
+		}
+		if idx == t.cursor {
+			style += ansiUnderline
+			cursorColumn, cursorWidth = column, utf8.RuneCountInString(label)
+		}
+		fmt.Fprintf(&b, "%s%s%s  ", style, label, ansiReset)
+		column += utf8.RuneCountInString(label) + 2
+	}
+	b.WriteString("\n")
+	if cursorWidth > 0 {
+		b.WriteString(strings.Repeat(" ", cursorColumn) + strings.Repeat("^", cursorWidth))
+	}
+	b.WriteString("\n")
+	if t.cursor < len(g.PlayerHand) {
+		card := g.PlayerHand[t.cursor]
+		detail := card.String()
+		if card.Enhancement != NoEnhancement && !card.FaceDown {
+			detail += " - " + card.Enhancement.String() + " card"
+		}
+		if !card.FaceDown && g.ActiveBoss().Debuffs(card) {
+			detail += " (debuffed)"
+		}
+		fmt.Fprintf(&b, " %s\n", detail)
+	}
+
+	fmt.Fprintf(&b, "\n Selected (%d/%d): %s\n", len(t.selected), MaxSelection, t.preview())
+	if t.tally != "" {
+		fmt.Fprintf(&b, " %s\n", t.tally)
+	}
+	if t.message != "" {
+		fmt.Fprintf(&b, " %s\n", strings.TrimSpace(t.message))
+	}
+	if t.panel != "" {
+		fmt.Fprintf(&b, "\n%s", t.panel)
+	}
+	fmt.Fprintf(&b, "\n%s%s%s\n", ansiDim, tuiHelp, ansiReset)
+
+	t.out.WriteString(b.String())
+	t.out.Flush()
+}
+
+// preview scores the selected cards without playing them. Hands with face
+// down cards aren't previewed since that would give the cards away.
+func (t *tui) preview() string {
+	indices := t.selectedIndices()
+	if len(indices) == 0 {
+		return "nothing"
+	}
+	for _, idx := range indices {
+		if t.g.PlayerHand[idx].FaceDown {
+			return t.g.selectedHand(indices).String()
+		}
+	}
+	selected, held, err := t.g.splitHand(indices)
+	if err != nil {
+		return err.Error()
+	}
+	ctx := t.g.ScoringContext(held)
+	ctx.Rand = nil
+	e := EvaluateHandWithContext(selected, ctx)
+	if e.NotAllowed {
+		return fmt.Sprintf("%s %s, not allowed by %s", e.Type, selected, ctx.Boss)
+	}
+	return fmt.Sprintf("%s %s: %d chips x %.1f mult = %d", e.Type, selected, e.CardValue, e.Mult, e.TotalScore)
+}
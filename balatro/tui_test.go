@@ -0,0 +1,75 @@
+package balatro
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReadKey(t *testing.T) {
+	reader := bufio.NewReader(strings.NewReader("\x1b[C\x1b[D p\r\x03"))
+	expected := []struct {
+		k key
+		c byte
+	}{
+		{keyRight, 0}, {keyLeft, 0}, {keySpace, 0}, {keyRune, 'p'}, {keyEnter, 0}, {keyInterrupt, 0},
+	}
+	for i, want := range expected {
+		k, c, err := readKey(reader)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if k != want.k || c != want.c {
+			t.Errorf("Key %d: expected %v %q, got %v %q", i, want.k, want.c, k, c)
+		}
+	}
+	if _, _, err := readKey(reader); err == nil {
+		t.Error("Expected an error once the input runs out")
+	}
+}
+
+func TestSortHand(t *testing.T) {
+	g := newTestGame(38)
+	g.PlayerHand = []Card{
+		{Suit: Spades, Rank: Four, ID: 1},
+		{Suit: Hearts, Rank: King, ID: 2, FaceDown: true},
+		{Suit: Hearts, Rank: Two, ID: 3},
+		{Suit: Clubs, Rank: Ace, ID: 4},
+		{Suit: Hearts, Rank: Jack, ID: 5},
+	}
+
+	g.SortHand(false)
+	if ids := cardIDs(g.PlayerHand); ids != "4 5 1 3 2" {
+		t.Errorf("Expected rank order with the face down card last, got %s", ids)
+	}
+	g.SortHand(true)
+	if ids := cardIDs(g.PlayerHand); ids != "5 3 4 1 2" {
+		t.Errorf("Expected suit order with the face down card last, got %s", ids)
+	}
+}
+
+func cardIDs(cards []Card) string {
+	ids := make([]string, 0, len(cards))
+	for _, card := range cards {
+		ids = append(ids, strconv.Itoa(card.ID))
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestCardLabel(t *testing.T) {
+	tests := []struct {
+		card     Card
+		expected string
+	}{
+		{Card{Suit: Hearts, Rank: Ten}, "10♥"},
+		{Card{Suit: Spades, Rank: Ace, Seal: RedSeal}, "A♠*"},
+		{Card{Suit: Clubs, Rank: Two, Enhancement: StoneCard}, "Stone"},
+		{Card{Suit: Clubs, Rank: Two, FaceDown: true}, "??"},
+	}
+	for _, tt := range tests {
+		if got := cardLabel(tt.card); got != tt.expected {
+			t.Errorf("cardLabel(%+v) = %q, want %q", tt.card, got, tt.expected)
+		}
+	}
+}
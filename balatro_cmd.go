@@ -0,0 +1,115 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"kevinmchugh.me/yahtzee/m/v2/balatro"
+)
+
+// runFlags are the flags that pick the kind of Balatro run
+type runFlags struct {
+	deck  *string
+	stake *string
+	seed  *string
+}
+
+func addRunFlags(flags *flag.FlagSet) runFlags {
+	return runFlags{
+		deck:  flags.String("deck", "Standard", "starting deck: Standard, Red, Blue, Abandoned, Checkered or Erratic"),
+		stake: flags.String("stake", "White", "stake: White, Red, Green, Black, Blue, Purple or Orange"),
+		seed:  flags.String("seed", "", "run seed to replay, up to 8 letters and digits; random if empty"),
+	}
+}
+
+func (f runFlags) config() (balatro.RunConfig, error) {
+	deck, err := balatro.ParseDeckKind(*f.deck)
+	if err != nil {
+		return balatro.RunConfig{}, usageError{err.Error()}
+	}
+	stake, err := balatro.ParseStake(*f.stake)
+	if err != nil {
+		return balatro.RunConfig{}, usageError{err.Error()}
+	}
+	seed := ""
+	if *f.seed != "" {
+		seed, err = balatro.ParseSeed(*f.seed)
+		if err != nil {
+			return balatro.RunConfig{}, usageError{err.Error()}
+		}
+	}
+	return balatro.RunConfig{Deck: deck, Stake: stake, Seed: seed}, nil
+}
+
+func balatroPlay(name string, args []string) error {
+	flags := newFlagSet(name, "", "Play a run of Balatro. The run is saved after every action.")
+	run := addRunFlags(flags)
+	resume := flags.Bool("continue", false, "continue the saved run instead of starting a new one")
+	savePath := flags.String("save", balatro.DefaultSavePath(), "file the run is saved to after every action")
+	plain := flags.Bool("plain", false, "use line prompts even in a terminal")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := noArguments(flags); err != nil {
+		return err
+	}
+
+	play := (*balatro.Game).Play
+	if *plain {
+		play = (*balatro.Game).PlayPrompt
+	}
+
+	if *resume {
+		game, err := balatro.LoadGame(*savePath)
+		if err != nil {
+			return fmt.Errorf("could not continue the saved run: %w", err)
+		}
+		play(game)
+		return nil
+	}
+
+	config, err := run.config()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(*savePath); err == nil {
+		fmt.Println("A saved run exists. Start with -continue to resume it; starting a new run replaces it.")
+	}
+	game := balatro.NewGame(config)
+	game.SavePath = *savePath
+	play(game)
+	return nil
+}
+
+func balatroSim(name string, args []string) error {
+	flags := newFlagSet(name, "", "Play many runs with a strategy and report how many cleared each ante.")
+	run := addRunFlags(flags)
+	runs := flags.Int("runs", 100, "number of runs to play")
+	strategyName := flags.String("strategy", "greedy", "strategy to play with: greedy or lookahead")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := noArguments(flags); err != nil {
+		return err
+	}
+	if *runs < 1 {
+		return usageError{fmt.Sprintf("-runs must be at least 1, got %d", *runs)}
+	}
+
+	config, err := run.config()
+	if err != nil {
+		return err
+	}
+	strategy, err := balatro.ParseStrategy(*strategyName, 1)
+	if err != nil {
+		return usageError{err.Error()}
+	}
+	simulation := balatro.Simulation{
+		Strategy: strategy,
+		Config:   config,
+		Runs:     *runs,
+	}
+	simulation.Run().Report(os.Stdout)
+	return nil
+}
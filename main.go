@@ -1,18 +1,149 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-
-	"kevinmchugh.me/yahtzee/m/v2/balatro"
+	"path/filepath"
 )
 
+// command is one leaf of the launcher's command tree, e.g. "yahtzee sim"
+type command struct {
+	game    string
+	name    string
+	summary string
+	run     func(name string, args []string) error
+}
+
+var commands = []command{
+	{"balatro", "play", "play a run of Balatro", balatroPlay},
+	{"balatro", "sim", "play many runs with a strategy and report how far they got", balatroSim},
+	{"yahtzee", "play", "play Yahtzee against people and AIs", yahtzeePlay},
+	{"yahtzee", "resume", "carry on with the saved Yahtzee game", yahtzeeResume},
+	{"yahtzee", "export", "write the saved game's scorecards as plain text", yahtzeeExport},
+	{"yahtzee", "sim", "play many AI-only games and report the scores", yahtzeeSim},
+	{"yahtzee", "tournament", "rank AIs by Elo over games with shared dice", yahtzeeTournament},
+	{"starbattle", "solve", "solve the Star Battle puzzle in a file", starbattleSolve},
+	{"starbattle", "play", "place stars on a Star Battle puzzle yourself", starbattlePlay},
+}
+
+// usageError is bad input on the command line, reported with exit code 2
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
 func main() {
-	if len(os.Args) > 1 && os.Args[1] == "yahtzee" {
-		fmt.Println("Yahtzee mode is deprecated in this Balatro build")
-		os.Exit(1)
+	program := filepath.Base(os.Args[0])
+	os.Exit(launch(program, os.Args[1:]))
+}
+
+// launch runs the command named by args and returns the exit code
+func launch(program string, args []string) int {
+	if len(args) == 0 {
+		printUsage(os.Stderr, program, "")
+		return 2
+	}
+	if isHelp(args[0]) {
+		printUsage(os.Stdout, program, "")
+		return 0
+	}
+
+	game := args[0]
+	if !knownGame(game) {
+		fmt.Fprintf(os.Stderr, "unknown game %q\n\n", game)
+		printUsage(os.Stderr, program, "")
+		return 2
+	}
+	if len(args) == 1 {
+		printUsage(os.Stderr, program, game)
+		return 2
+	}
+	if isHelp(args[1]) {
+		printUsage(os.Stdout, program, game)
+		return 0
+	}
+
+	for _, cmd := range commands {
+		if cmd.game != game || cmd.name != args[1] {
+			continue
+		}
+		err := cmd.run(program+" "+game+" "+cmd.name, args[2:])
+		var usage usageError
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.As(err, &usage):
+			if usage.msg != "" {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			return 2
+		default:
+			fmt.Fprintln(os.Stderr, "error:", err)
+			return 1
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown %s command %q\n\n", game, args[1])
+	printUsage(os.Stderr, program, game)
+	return 2
+}
+
+func isHelp(arg string) bool {
+	return arg == "help" || arg == "-h" || arg == "-help" || arg == "--help"
+}
+
+func knownGame(game string) bool {
+	for _, cmd := range commands {
+		if cmd.game == game {
+			return true
+		}
+	}
+	return false
+}
+
+// printUsage lists the commands, or only one game's commands if game is set
+func printUsage(w io.Writer, program, game string) {
+	fmt.Fprintf(w, "Usage: %s <game> <command> [flags]\n\nCommands:\n", program)
+	for _, cmd := range commands {
+		if game == "" || cmd.game == game {
+			fmt.Fprintf(w, "  %-18s %s\n", cmd.game+" "+cmd.name, cmd.summary)
+		}
+	}
+	fmt.Fprintf(w, "\nRun '%s <game> <command> --help' for a command's flags.\n", program)
+}
+
+// newFlagSet makes a flag set whose usage names the command and its arguments
+func newFlagSet(name, arguments, summary string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s [flags]%s\n\n%s\n\nFlags:\n", name, arguments, summary)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// parseFlags parses args, turning bad flags into a usage error. The flag
+// package has already printed what was wrong along with the usage.
+func parseFlags(flags *flag.FlagSet, args []string) error {
+	err := flags.Parse(args)
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return usageError{}
+}
+
+// noArguments rejects anything left over after the flags
+func noArguments(flags *flag.FlagSet) error {
+	if flags.NArg() > 0 {
+		return usageError{fmt.Sprintf("%s takes no arguments, got %q", flags.Name(), flags.Arg(0))}
 	}
-	
-	game := balatro.NewGame()
-	game.Play()
+	return nil
 }
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"kevinmchugh.me/yahtzee/m/v2/yahtzee"
+)
+
+// maxPuzzleSize is the number of column letters the puzzle knows
+const maxPuzzleSize = 10
+
+// readPuzzle reads a puzzle file: one row per line, one colored square per
+// cell. Blank lines and lines starting with # are skipped.
+func readPuzzle(path string, stars int) (*yahtzee.Puzzle, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []string
+	for _, line := range strings.Split(string(data), "\n") {
+		// emoji variation selectors would otherwise count as cells of their own
+		line = strings.TrimSpace(strings.ReplaceAll(line, "\ufe0f", ""))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rows = append(rows, line)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%s has no puzzle rows", path)
+	}
+	width := utf8.RuneCountInString(rows[0])
+	if len(rows) != width {
+		return nil, fmt.Errorf("%s is %d wide and %d tall, puzzles must be square", path, width, len(rows))
+	}
+	if width > maxPuzzleSize {
+		return nil, fmt.Errorf("%s is %d wide, puzzles can be at most %d", path, width, maxPuzzleSize)
+	}
+	return yahtzee.ParsePuzzle(rows, stars)
+}
+
+func starbattleSolve(name string, args []string) error {
+	flags := newFlagSet(name, " <file>", "Solve the puzzle in the file and print the solution.")
+	stars := flags.Int("stars", 1, "stars in every row, column and region")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		return usageError{fmt.Sprintf("usage: %s [flags] <file>", name)}
+	}
+	if *stars < 1 {
+		return usageError{fmt.Sprintf("-stars must be at least 1, got %d", *stars)}
+	}
+
+	puzzle, err := readPuzzle(flags.Arg(0), *stars)
+	if err != nil {
+		return err
+	}
+	puzzle.Print("Puzzle:")
+	solution, ok := yahtzee.Solve(*puzzle)
+	if !ok {
+		return fmt.Errorf("no solution found")
+	}
+	solution.Print("Solution:")
+	return nil
+}
+
+func starbattlePlay(name string, args []string) error {
+	flags := newFlagSet(name, "", "Place stars on a puzzle by typing cells like B3. Without -file a built in 5x5 puzzle is used.")
+	path := flags.String("file", "", "puzzle file to play")
+	stars := flags.Int("stars", 1, "stars in every row, column and region of the -file puzzle")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := noArguments(flags); err != nil {
+		return err
+	}
+	if *stars < 1 {
+		return usageError{fmt.Sprintf("-stars must be at least 1, got %d", *stars)}
+	}
+	if *path == "" && *stars != 1 {
+		return usageError{fmt.Sprintf("the built in puzzle has 1 star per region, -stars %d needs a -file", *stars)}
+	}
+
+	var puzzle *yahtzee.Puzzle
+	if *path == "" {
+		easy := yahtzee.MakeEasyPuzzle()
+		puzzle = &easy
+	} else {
+		var err error
+		puzzle, err = readPuzzle(*path, *stars)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Println("Type a cell like B3 to star it, 'solve' to see the answer or 'quit'.")
+	reader := bufio.NewReader(os.Stdin)
+	for {
+		puzzle.Print("")
+		if starCount(puzzle) == puzzle.Height*puzzle.CorrectStarsPerArea && puzzle.Solved() {
+			fmt.Println("Solved!")
+			return nil
+		}
+
+		fmt.Print("> ")
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return nil
+		}
+		input := strings.ToUpper(strings.TrimSpace(line))
+		switch input {
+		case "":
+			continue
+		case "QUIT", "Q":
+			return nil
+		case "SOLVE":
+			solution, ok := yahtzee.Solve(*puzzle.DeepCopy())
+			if !ok {
+				fmt.Println("These stars can't be part of a solution.")
+				continue
+			}
+			solution.Print("Solution:")
+			return nil
+		}
+
+		column, row, err := parseCell(input)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		if _, err := puzzle.Star(row, column); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// parseCell parses a cell name like "B3" into its column and row
+func parseCell(input string) (string, int, error) {
+	if len(input) < 2 {
+		return "", 0, fmt.Errorf("%q is not a cell, type a column letter and row number like B3", input)
+	}
+	row, err := strconv.Atoi(input[1:])
+	if err != nil {
+		return "", 0, fmt.Errorf("%q is not a cell, type a column letter and row number like B3", input)
+	}
+	return input[:1], row, nil
+}
+
+func starCount(puzzle *yahtzee.Puzzle) int {
+	count := 0
+	for _, row := range puzzle.Rows() {
+		for _, cell := range row {
+			if cell.State == yahtzee.Starred {
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -2,17 +2,29 @@ package yahtzee
 
 import (
 	"fmt"
+	"io"
+	"os"
 )
 
 type AIPlayer struct {
-	Scorecard
+	*Scorecard
+	Name string
+	// Rules are the rules of the game the AI is playing
+	Rules Rules
+	// Log is where the AI explains its choices, nothing is written if nil
+	Log io.Writer
 }
 
-func NewAiPlayer() *Player {
-	z := 0
-	scoreCard := Scorecard{YahtzeeBonusName: &z}
+func NewAiPlayer(name string) *Player {
+	return NewLoggingAiPlayer(name, os.Stdout)
+}
+
+// NewLoggingAiPlayer makes an AI player that explains its choices to log
+func NewLoggingAiPlayer(name string, log io.Writer) *Player {
 	ai := AIPlayer{
-		Scorecard: scoreCard,
+		Scorecard: &Scorecard{},
+		Name:      name,
+		Log:       log,
 	}
 
 	p := Player(ai)
@@ -20,113 +32,138 @@ func NewAiPlayer() *Player {
 	return &p
 }
 
+// explain writes a rationale to the log, if there is one
+func (ai AIPlayer) explain(rationale fmt.Stringer) {
+	if ai.Log != nil {
+		fmt.Fprintf(ai.Log, "%s: %s", ai.GetName(), rationale)
+	}
+}
+
 func (ai AIPlayer) GetName() string {
-	return "🤖"
+	if ai.Name == "" {
+		return "🤖"
+	}
+	return ai.Name
 }
 
 func (ai AIPlayer) GetScorecard() *Scorecard {
-	return &ai.Scorecard
+	return ai.Scorecard
+}
+
+// jokers reports whether a Yahtzee can score as a full house or straight
+func (ai AIPlayer) jokers() bool {
+	return !ai.Rules.NoJokers && ai.Scorecard.HadYahztee()
 }
 
 // TODO should be bonus-aware
 func (ai AIPlayer) AssessRoll(hand Hand, rollsRemaining int) RollDecision {
+	rationale := ai.ExplainRoll(hand, rollsRemaining)
+	ai.explain(rationale)
+	return rationale.Keep
+}
+
+// ExplainRoll weighs chasing each open category with the hand and decides
+// which dice to hold
+func (ai AIPlayer) ExplainRoll(hand Hand, rollsRemaining int) RollRationale {
+	rationale := RollRationale{
+		Hand:           hand,
+		RollsRemaining: rollsRemaining,
+		// chase Chance if nothing else is worth it
+		Target: ChanceCategory,
+	}
 	// calculate a targeted scorable, given incomplete scorables and probabilites of completion
 	bestProportion := 0.0
-	var bestScorableName ScorableName
-	// fmt.Println(hand)
-	for _, name := range ScorableNames {
-		scorable := ScoreableByName(name)
-		if scorable == nil || ai.Scorecard.NameToScorePtr(name) != nil || name == ChanceName {
+	for category := Category(0); category < CategoryCount; category++ {
+		if ai.Scorecard.Filled(category) || category == ChanceCategory {
 			continue
 		}
+		scorable := category.Scoreable()
 		prob := scorable.ProbabilityToHit(hand, rollsRemaining)
 		max := scorable.MaxPossible()
+		keep := StrategyForScorable(category).PickKeepers(hand)
+		if category.Variety() == StraightVariety && isJoker(hand, ai.jokers()) {
+			// the joker already scores the straight, so there's nothing to chase
+			prob, keep = 1, RollDecision{true, true, true, true, true}
+		}
 
 		expected := prob * float64(max)
 		proportion := expected / float64(max)
-		if name == LargeStraightName && prob < 1.0 {
+		if category == LargeStraightCategory && prob < 1.0 {
 			// arbitrary but decent
 			proportion -= 0.5
 		}
-		if name.VarietyOfScorable() == FaceValueVariety {
+		if category.Variety() == FaceValueVariety {
 			if prob >= 1.0 { // I cheated probability and called it out of 3, to prioritize bonus
 				proportion += 0.25
 			}
 		}
-		fmt.Printf("	%s, %.2f, %d, %.2f\n", name, prob, max, proportion)
+		rationale.Candidates = append(rationale.Candidates, KeepCandidate{
+			Category:    category,
+			Keep:        keep,
+			Probability: prob,
+			Expected:    expected,
+			Weight:      proportion,
+		})
 		if proportion >= bestProportion {
 			bestProportion = proportion
-			bestScorableName = name
+			rationale.Target = category
+			rationale.Keep = keep
 		}
 		// TODO if expected == score then short circuit and return all keeps
 	}
 
-	if bestScorableName == "" {
-		bestScorableName = ChanceName
+	if rationale.Keep == nil {
+		rationale.Keep = StrategyForScorable(rationale.Target).PickKeepers(hand)
 	}
-
-	strategy := StrategyForScorable(bestScorableName)
-	// this seems to happen when all the unselected scorables have a 0 probability.
-	// The >= on 46 should stop it.
-	if strategy == nil {
-		fmt.Println("picking a nil strategy for some reason", bestScorableName)
-	}
-	decision := strategy.PickKeepers(hand)
-	fmt.Printf("roll: %v; hand: %d; chasing: %s; holding: %v\n", hand, rollsRemaining, bestScorableName, decision)
-	return decision
+	return rationale
 }
 
 func (ai AIPlayer) PickScorable(hand Hand) Scoreable {
+	rationale := ai.ExplainScore(hand)
+	ai.explain(rationale)
+	return rationale.Chosen.Scoreable()
+}
+
+// ExplainScore weighs scoring the hand in each open category and picks one
+func (ai AIPlayer) ExplainScore(hand Hand) ScoreRationale {
+	rationale := ScoreRationale{
+		Hand: hand,
+		// We didn't find anything worth scoring, throw it in Chance.
+		Chosen: ChanceCategory,
+	}
 	highestScore := 0
-	var highestScorable ScorableName
-	for _, name := range ScorableNames {
-		scorable := ScoreableByName(name)
-		// TODO maybe a NullScorable?
-		if scorable == nil || ai.Scorecard.NameToScorePtr(name) != nil || name == ChanceName {
+	for category := Category(0); category < CategoryCount; category++ {
+		if ai.Scorecard.Filled(category) || category == ChanceCategory {
 			continue
 		}
-		score := scorable.Score(hand, ai.Scorecard.HadYahztee())
-		if name.VarietyOfScorable() == FaceValueVariety {
-			if scorable.ProbabilityToHit(hand, 0) > 1.0 { // I cheated probability and called it out of 3, to prioritize bonus
-				score += 10
+		candidate := ScoreCandidate{
+			Category: category,
+			Score:    category.Scoreable().Score(hand, ai.jokers()),
+		}
+		if category.Variety() == FaceValueVariety {
+			if category.Scoreable().ProbabilityToHit(hand, 0) > 1.0 { // I cheated probability and called it out of 3, to prioritize bonus
+				candidate.Bonus = 10
 			}
 		}
+		rationale.Candidates = append(rationale.Candidates, candidate)
 		// prefer harder ones, or maybe compare to best possible score
-		if score >= int(highestScore) {
-			highestScore = score
-			highestScorable = name
+		if candidate.Weight() >= highestScore {
+			highestScore = candidate.Weight()
+			rationale.Chosen = category
 		}
 	}
-
-	// We didn't find anything worth scoring, throw it in Chance.
-	if highestScorable == "" {
-		highestScorable = ChanceName
-	}
-
-	dec := ScoreableByName(highestScorable)
-	fmt.Printf("given %x, choosing %s\n", hand, highestScorable)
-	// fmt.Println(hand, "-", highestScorable)
-
-	return dec
+	return rationale
 }
 
-func NewFaceValueStrategy(name ScorableName) ScorableVarietyStrategy {
-	namesToNumbers := map[ScorableName]int{
-		OnesName:   1,
-		TwosName:   2,
-		ThreesName: 3,
-		FoursName:  4,
-		FivesName:  5,
-		SixesName:  6,
-	}
-	return FaceValueStrategy{namesToNumbers[name]}
+func NewFaceValueStrategy(category Category) ScorableVarietyStrategy {
+	return FaceValueStrategy{category.Face()}
 }
 
-func StrategyForScorable(name ScorableName) ScorableVarietyStrategy {
-	variety := name.VarietyOfScorable()
+func StrategyForScorable(category Category) ScorableVarietyStrategy {
+	variety := category.Variety()
 	// TODO: we can probably get rid of the ScorableVariety type honestly.
 	strategyMap := map[ScorableVariety]ScorableVarietyStrategy{
-		FaceValueVariety: NewFaceValueStrategy(name),
+		FaceValueVariety: NewFaceValueStrategy(category),
 		// TODO: Build a strategy for each ScorableVariety:
 		// StraightStrategy keeps one of each (eventually handle the 1/6 thing)
 		// FullHouseStrategy is a bespoke little snowflake
@@ -163,9 +200,11 @@ func (s OfAKindValueStrategy) PickKeepers(hand Hand) RollDecision {
 	keep := make([]bool, 5)
 	counts := valueCounts(hand)
 	mostPresentValue, mostPresentCount := 1, 0
-	for idx, count := range counts {
-		if count >= mostPresentCount {
-			mostPresentValue = idx
+	// walk the faces in order so ties always go to the higher face, which a
+	// range over the map wouldn't guarantee
+	for value := 1; value <= 6; value++ {
+		if count := counts[value]; count > 0 && count >= mostPresentCount {
+			mostPresentValue = value
 			mostPresentCount = count
 		}
 	}
@@ -0,0 +1,226 @@
+package yahtzee
+
+import "sort"
+
+// categoryPar is roughly what an expert averages in each category over a
+// game. Scoring a hand is judged against it, so a zero in Ones costs little
+// and a zero in Yahtzee costs a lot.
+var categoryPar = [CategoryCount]float64{
+	1.88, 5.28, 8.57, 12.16, 15.69, 19.19,
+	21.66, 13.10, 22.59, 29.46, 32.71, 22.01, 16.87,
+}
+
+// Tuning adjusts how an Analysis values a score. It's what sets the AI
+// personalities apart, and can be loaded from a file with LoadAITuning.
+type Tuning struct {
+	// UpperPointWorth is how much each upper section point above or below
+	// three of a face is worth towards the bonus
+	UpperPointWorth float64 `json:"upper_point_worth"`
+	// UpperBonusWorth scales the value of earning the upper bonus
+	UpperBonusWorth float64 `json:"upper_bonus_worth"`
+	// YahtzeeWorth is extra value for scoring a Yahtzee
+	YahtzeeWorth float64 `json:"yahtzee_worth"`
+	// StandAt is how good a hand has to be for a player to stop rolling:
+	// points over par for risk-averse, plain points for greedy-immediate
+	StandAt float64 `json:"stand_at"`
+}
+
+// DefaultTuning values scores for the best expected total
+var DefaultTuning = Tuning{UpperPointWorth: 0.4, UpperBonusWorth: 1}
+
+// Analysis finds the keeps and category with the best expected value for a
+// turn. It looks ahead to the end of the turn and stands in for the rest of
+// the game by comparing scores to categoryPar.
+//
+// An Analysis remembers what it has worked out, so make a new one whenever
+// the scorecard changes.
+type Analysis struct {
+	Scorecard *Scorecard
+	Rules     Rules
+	Tuning    Tuning
+
+	// values caches the value of each hand, keyed by handKey, for 0, 1 and 2
+	// rolls remaining
+	values [3]map[int]float64
+	// keepValues caches the value of holding each set of dice, keyed by
+	// handKey, with 1 and 2 rolls remaining
+	keepValues [3]map[int]float64
+}
+
+// NewAnalysis analyses turns for the scorecard under the rules
+func NewAnalysis(scorecard *Scorecard, rules Rules) *Analysis {
+	return &Analysis{Scorecard: scorecard, Rules: rules, Tuning: DefaultTuning}
+}
+
+// CategoryValue is what scoring the hand in the category is worth compared
+// to par, including any upper or Yahtzee bonus it earns
+func (a *Analysis) CategoryValue(category Category, hand Hand) float64 {
+	score := category.Scoreable().Score(hand, !a.Rules.NoJokers && a.Scorecard.HadYahztee())
+	value := float64(score) - categoryPar[category]
+	if category.Upper() {
+		if subtotal := a.Scorecard.Subtotal(); subtotal < UpperBonusThreshold {
+			if subtotal+score >= UpperBonusThreshold {
+				value += UpperBonusPoints * a.Tuning.UpperBonusWorth
+			} else {
+				value += float64(score-3*category.Face()) * a.Tuning.UpperPointWorth
+			}
+		}
+	}
+	if category == YahtzeeCategory && score > 0 {
+		value += a.Tuning.YahtzeeWorth
+	}
+	if !a.Rules.NoYahtzeeBonus && a.Scorecard.HadYahztee() && isYahtzee(hand) {
+		value += YahtzeeBonusPoints
+	}
+	return value
+}
+
+// BestCategory is the open category the hand is worth most in, and its value
+func (a *Analysis) BestCategory(hand Hand) (Category, float64) {
+	best, bestValue := ChanceCategory, 0.0
+	found := false
+	for _, category := range Categories() {
+		if a.Scorecard.Filled(category) {
+			continue
+		}
+		if value := a.CategoryValue(category, hand); !found || value > bestValue {
+			best, bestValue, found = category, value, true
+		}
+	}
+	return best, bestValue
+}
+
+// HandValue is the expected value of the hand, playing the rest of the turn
+// as well as possible
+func (a *Analysis) HandValue(hand Hand, rollsRemaining int) float64 {
+	key := handKey(hand[:])
+	if value, ok := a.cached(&a.values, rollsRemaining, key); ok {
+		return value
+	}
+	var value float64
+	if rollsRemaining == 0 {
+		_, value = a.BestCategory(hand)
+	} else {
+		_, value = a.BestKeep(hand, rollsRemaining)
+	}
+	a.values[rollsRemaining][key] = value
+	return value
+}
+
+// BestKeep is the dice to hold with the best expected value, and that value
+func (a *Analysis) BestKeep(hand Hand, rollsRemaining int) (RollDecision, float64) {
+	var best RollDecision
+	bestValue := 0.0
+	seen := map[int]bool{}
+	for mask := 0; mask < 1<<len(hand); mask++ {
+		keep := make(RollDecision, len(hand))
+		for idx := range hand {
+			keep[idx] = mask&(1<<idx) != 0
+		}
+		kept := keptDice(hand, keep)
+		key := handKey(kept)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if value := a.keepValue(kept, rollsRemaining); best == nil || value > bestValue {
+			best, bestValue = keep, value
+		}
+	}
+	return best, bestValue
+}
+
+// KeepValue is the expected value of holding the dice and rolling the rest
+func (a *Analysis) KeepValue(hand Hand, keep RollDecision, rollsRemaining int) float64 {
+	return a.keepValue(keptDice(hand, keep), rollsRemaining)
+}
+
+func (a *Analysis) keepValue(kept []int, rollsRemaining int) float64 {
+	if len(kept) == len(Hand{}) || rollsRemaining == 0 {
+		var hand Hand
+		copy(hand[:], kept)
+		return a.HandValue(hand, 0)
+	}
+	key := handKey(kept)
+	if value, ok := a.cached(&a.keepValues, rollsRemaining, key); ok {
+		return value
+	}
+
+	value := 0.0
+	for _, outcome := range rerolls(len(Hand{}) - len(kept)) {
+		var hand Hand
+		copy(hand[:], kept)
+		copy(hand[len(kept):], outcome.dice)
+		sort.Ints(hand[:])
+		value += outcome.probability * a.HandValue(hand, rollsRemaining-1)
+	}
+	a.keepValues[rollsRemaining][key] = value
+	return value
+}
+
+func (a *Analysis) cached(cache *[3]map[int]float64, rollsRemaining, key int) (float64, bool) {
+	if cache[rollsRemaining] == nil {
+		cache[rollsRemaining] = map[int]float64{}
+	}
+	value, ok := cache[rollsRemaining][key]
+	return value, ok
+}
+
+// handKey identifies a set of dice regardless of their order
+func handKey(dice []int) int {
+	key := 0
+	for _, die := range dice {
+		key += pow(7, die-1)
+	}
+	return key
+}
+
+func pow(base, n int) int {
+	result := 1
+	for ; n > 0; n-- {
+		result *= base
+	}
+	return result
+}
+
+// reroll is one way some dice can land, ignoring order
+type reroll struct {
+	dice        []int
+	probability float64
+}
+
+var rerollOutcomes [len(Hand{}) + 1][]reroll
+
+func init() {
+	for count := range rerollOutcomes {
+		rerollOutcomes[count] = enumerateRerolls(count)
+	}
+}
+
+// rerolls lists every way count dice can land
+func rerolls(count int) []reroll {
+	return rerollOutcomes[count]
+}
+
+// enumerateRerolls walks every ordered roll of count dice and merges those
+// with the same dice
+func enumerateRerolls(count int) []reroll {
+	total := pow(6, count)
+	byKey := map[int]int{}
+	var outcomes []reroll
+	for roll := 0; roll < total; roll++ {
+		dice := make([]int, count)
+		for idx, rest := 0, roll; idx < count; idx, rest = idx+1, rest/6 {
+			dice[idx] = rest%6 + 1
+		}
+		sort.Ints(dice)
+		key := handKey(dice)
+		if idx, ok := byKey[key]; ok {
+			outcomes[idx].probability += 1 / float64(total)
+			continue
+		}
+		byKey[key] = len(outcomes)
+		outcomes = append(outcomes, reroll{dice: dice, probability: 1 / float64(total)})
+	}
+	return outcomes
+}
@@ -0,0 +1,315 @@
+package yahtzee
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+)
+
+// botStyle is how one AI personality plays
+type botStyle struct {
+	description string
+	tuning      Tuning
+	keep        func(b *Bot, hand Hand, rollsRemaining int) RollDecision
+	pick        func(b *Bot, hand Hand) Category
+}
+
+// botStyles are the AI personalities by strategy name. A lineup seat can
+// replace a style's tuning with its own.
+var botStyles = map[string]*botStyle{
+	"greedy-immediate": {
+		description: "scores the most points it can right now and stops rolling once it's happy",
+		tuning:      Tuning{StandAt: 20},
+		keep:        greedyKeep,
+		pick:        greedyPick,
+	},
+	"bonus-chaser": {
+		description: "plays for the best total but leans hard towards the upper bonus",
+		tuning:      Tuning{UpperPointWorth: 1.2, UpperBonusWorth: 1.5},
+		keep:        optimalKeep,
+		pick:        optimalPick,
+	},
+	"yahtzee-hunter": {
+		description: "plays for the best total but will chase a Yahtzee at any cost",
+		tuning:      Tuning{UpperPointWorth: 0.4, UpperBonusWorth: 1, YahtzeeWorth: 40},
+		keep:        optimalKeep,
+		pick:        optimalPick,
+	},
+	"risk-averse": {
+		description: "plays for the best total but keeps any hand that beats par",
+		tuning:      Tuning{UpperPointWorth: 0.4, UpperBonusWorth: 1, StandAt: 0},
+		keep:        cautiousKeep,
+		pick:        optimalPick,
+	},
+	"optimal": {
+		description: "holds and scores for the best expected value this turn",
+		tuning:      DefaultTuning,
+		keep:        optimalKeep,
+		pick:        optimalPick,
+	},
+	"random": {
+		description: "holds dice and picks rows at random",
+		tuning:      Tuning{},
+		keep:        randomKeep,
+		pick:        randomPick,
+	},
+}
+
+// botPlayer makes the bot for a lineup seat, with the seat's tuning if it
+// has one
+func botPlayer(spec PlayerSpec, rules Rules, log io.Writer) *Player {
+	p := NewBot(spec.Name, spec.Strategy, rules, log)
+	if bot, ok := (*p).(*Bot); ok && spec.Tuning != nil {
+		bot.Tuning = *spec.Tuning
+	}
+	return p
+}
+
+// Bot is an AI player with one of the personalities in botStyles
+type Bot struct {
+	Scorecard *Scorecard
+	Name      string
+	Strategy  string
+	Tuning    Tuning
+	// Rules are the rules of the game the bot is playing
+	Rules Rules
+	// Log is where the bot explains its choices, nothing is written if nil
+	Log io.Writer
+
+	style  *botStyle
+	rng    *rand.Rand
+	source *rngSource
+	// analysis is reused until the scorecard changes
+	analysis *Analysis
+	analysed Scorecard
+}
+
+// NewBot makes an AI player with the named personality and its default
+// tuning, playing by the rules. It returns nil for an unknown strategy.
+func NewBot(name, strategy string, rules Rules, log io.Writer) *Player {
+	style, ok := botStyles[strategy]
+	if !ok {
+		return nil
+	}
+	bot := &Bot{
+		Scorecard: &Scorecard{},
+		Name:      name,
+		Strategy:  strategy,
+		Tuning:    style.tuning,
+		Rules:     rules,
+		Log:       log,
+		style:     style,
+	}
+	bot.rng, bot.source = newRand(0)
+	p := Player(bot)
+	return &p
+}
+
+// randomPlayer is a player that makes random choices. A game seeds each one
+// from its own seed and the player's seat, and saves where they're up to.
+type randomPlayer interface {
+	seedRandom(seed int64)
+	randomState() uint64
+	setRandomState(state uint64)
+}
+
+func (b *Bot) seedRandom(seed int64) {
+	b.source.Seed(seed)
+}
+
+func (b *Bot) randomState() uint64 {
+	return b.source.state
+}
+
+func (b *Bot) setRandomState(state uint64) {
+	b.source.state = state
+}
+
+func (b *Bot) GetName() string {
+	if b.Name == "" {
+		return "🤖 " + b.Strategy
+	}
+	return b.Name
+}
+
+func (b *Bot) GetScorecard() *Scorecard {
+	return b.Scorecard
+}
+
+func (b *Bot) AssessRoll(hand Hand, rollsRemaining int) RollDecision {
+	keep := b.style.keep(b, hand, rollsRemaining)
+	b.logf("%s, %d left: holding %s\n", formatDice(hand[:]), rollsRemaining, formatDice(keptDice(hand, keep)))
+	return keep
+}
+
+func (b *Bot) PickScorable(hand Hand) Scoreable {
+	category := b.style.pick(b, hand)
+	b.logf("%s: scoring %s\n", formatDice(hand[:]), category)
+	return category.Scoreable()
+}
+
+func (b *Bot) logf(format string, args ...interface{}) {
+	if b.Log != nil {
+		fmt.Fprintf(b.Log, "%s: "+format, append([]interface{}{b.GetName()}, args...)...)
+	}
+}
+
+// analyse is an analysis of the current scorecard with the bot's tuning
+func (b *Bot) analyse() *Analysis {
+	if b.analysis == nil || b.analysed != *b.Scorecard {
+		b.analysis = NewAnalysis(b.Scorecard, b.Rules)
+		b.analysis.Tuning = b.Tuning
+		b.analysed = *b.Scorecard
+	}
+	return b.analysis
+}
+
+// jokers reports whether a Yahtzee can score as a full house or straight
+func (b *Bot) jokers() bool {
+	return !b.Rules.NoJokers && b.Scorecard.HadYahztee()
+}
+
+// openCategories lists the categories left to score
+func (b *Bot) openCategories() []Category {
+	var open []Category
+	for _, category := range Categories() {
+		if !b.Scorecard.Filled(category) {
+			open = append(open, category)
+		}
+	}
+	return open
+}
+
+func optimalKeep(b *Bot, hand Hand, rollsRemaining int) RollDecision {
+	keep, _ := b.analyse().BestKeep(hand, rollsRemaining)
+	return keep
+}
+
+func optimalPick(b *Bot, hand Hand) Category {
+	category, _ := b.analyse().BestCategory(hand)
+	return category
+}
+
+// cautiousKeep stops rolling once the hand is worth StandAt over par
+func cautiousKeep(b *Bot, hand Hand, rollsRemaining int) RollDecision {
+	if _, value := b.analyse().BestCategory(hand); value >= b.Tuning.StandAt {
+		return RollDecision{true, true, true, true, true}
+	}
+	return optimalKeep(b, hand, rollsRemaining)
+}
+
+// greedyPick takes the most points on offer, preferring the higher row on
+// the scorecard on a tie
+func greedyPick(b *Bot, hand Hand) Category {
+	best, bestScore := ChanceCategory, -1
+	for _, category := range b.openCategories() {
+		if score := category.Scoreable().Score(hand, b.jokers()); score > bestScore {
+			best, bestScore = category, score
+		}
+	}
+	return best
+}
+
+// greedyKeep stands on StandAt points, otherwise holds towards whatever
+// would score the most right now
+func greedyKeep(b *Bot, hand Hand, rollsRemaining int) RollDecision {
+	category := greedyPick(b, hand)
+	if float64(category.Scoreable().Score(hand, b.jokers())) >= b.Tuning.StandAt {
+		return RollDecision{true, true, true, true, true}
+	}
+	return StrategyForScorable(category).PickKeepers(hand)
+}
+
+func randomKeep(b *Bot, hand Hand, rollsRemaining int) RollDecision {
+	keep := make(RollDecision, len(hand))
+	for idx := range keep {
+		keep[idx] = b.rng.Intn(2) == 1
+	}
+	return keep
+}
+
+func randomPick(b *Bot, hand Hand) Category {
+	open := b.openCategories()
+	return open[b.rng.Intn(len(open))]
+}
+
+// BotDescriptions describes each AI personality by strategy name
+func BotDescriptions() map[string]string {
+	descriptions := make(map[string]string, len(botStyles))
+	for name, style := range botStyles {
+		descriptions[name] = style.description
+	}
+	return descriptions
+}
+
+// AITunings are replacement tunings for AI personalities, by strategy name
+type AITunings map[string]Tuning
+
+// Apply gives every AI in the lineup whose strategy has a tuning that
+// tuning. The lineup is copied, not changed. Only the personalities in
+// botStyles can be tuned, a tuning for any other strategy is an error.
+func (t AITunings) Apply(lineup []PlayerSpec) ([]PlayerSpec, error) {
+	names := make([]string, 0, len(t))
+	for name := range t {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !tunable(name) {
+			return nil, fmt.Errorf("%q isn't a tunable AI strategy", name)
+		}
+	}
+
+	tuned := make([]PlayerSpec, len(lineup))
+	for idx, spec := range lineup {
+		tuned[idx] = spec
+		if tuning, ok := t[spec.Strategy]; ok && spec.Kind == AIKind {
+			tuned[idx].Tuning = &tuning
+		}
+	}
+	return tuned, nil
+}
+
+// tunable reports whether the strategy is a personality with a Tuning
+func tunable(strategy string) bool {
+	_, ok := botStyles[strategy]
+	return ok
+}
+
+// LoadAITuning reads JSON like {"bonus-chaser": {"upper_point_worth": 2}}
+// into tunings for the named personalities. Settings left out keep their
+// defaults.
+func LoadAITuning(path string) (AITunings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config map[string]json.RawMessage
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("reading AI tuning %s: %w", path, err)
+	}
+
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tunings := make(AITunings, len(config))
+	for _, name := range names {
+		if !tunable(name) {
+			return nil, fmt.Errorf("reading AI tuning %s: %q isn't a tunable AI strategy", path, name)
+		}
+		tuning := botStyles[name].tuning
+		decoder := json.NewDecoder(bytes.NewReader(config[name]))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&tuning); err != nil {
+			return nil, fmt.Errorf("reading AI tuning %s for %s: %w", path, name, err)
+		}
+		tunings[name] = tuning
+	}
+	return tunings, nil
+}
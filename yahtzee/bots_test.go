@@ -0,0 +1,146 @@
+package yahtzee
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEveryStrategyFinishesAGame(t *testing.T) {
+	names := AIStrategyNames()
+	lineup, err := ParseLineup("ai:" + strings.Join(names, ",ai:"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Simulation{Players: lineup, Games: 1, Seed: 3}.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Scores) != len(names) {
+		t.Fatalf("expected %d scores, got %d", len(names), len(result.Scores))
+	}
+
+	for idx, score := range result.Scores {
+		if score <= 0 {
+			t.Errorf("%s scored %d", names[idx], score)
+		}
+	}
+}
+
+func TestOptimalBeatsRandom(t *testing.T) {
+	mean := func(strategy string) float64 {
+		lineup, err := ParseLineup("ai:" + strategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		result, err := Simulation{Players: lineup, Games: 5, Seed: 1}.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Mean()
+	}
+	if optimal, random := mean("optimal"), mean("random"); optimal <= random+50 {
+		t.Errorf("optimal averaged %.1f against random's %.1f", optimal, random)
+	}
+}
+
+func TestBotsPlayByTheGamesRules(t *testing.T) {
+	// a second Yahtzee is only a large straight when jokers are allowed
+	hand := Hand{4, 4, 4, 4, 4}
+	for _, rules := range []Rules{{}, {NoJokers: true}} {
+		for _, strategy := range []string{"greedy-immediate", "optimal"} {
+			bot := *NewBot("", strategy, rules, nil)
+			bot.GetScorecard().Set(YahtzeeCategory, 50)
+			bot.GetScorecard().Set(FoursCategory, 16)
+			straight := bot.PickScorable(hand).Category() == LargeStraightCategory
+			if straight == rules.NoJokers {
+				t.Errorf("%s with %+v scored %s as a large straight: %v", strategy, rules, formatDice(hand[:]), straight)
+			}
+		}
+	}
+}
+
+func TestGreedyPlaysByTheGamesRules(t *testing.T) {
+	hand := Hand{4, 4, 4, 4, 4}
+	for _, rules := range []Rules{{}, {NoJokers: true}} {
+		greedy := *PlayerSpec{Kind: AIKind, Strategy: "greedy"}.NewPlayer(rules, nil)
+		greedy.GetScorecard().Set(YahtzeeCategory, 50)
+		greedy.GetScorecard().Set(FoursCategory, 16)
+		straight := greedy.PickScorable(hand).Category() == LargeStraightCategory
+		if straight == rules.NoJokers {
+			t.Errorf("greedy with %+v scored %s as a large straight: %v", rules, formatDice(hand[:]), straight)
+		}
+		roll := greedy.(AIPlayer).ExplainRoll(hand, 2)
+		if chasing := roll.Target == LargeStraightCategory; chasing == rules.NoJokers {
+			t.Errorf("greedy with %+v chased %s with %s", rules, roll.Target, formatDice(hand[:]))
+		}
+		if !roll.Keep.WillKeepAll() {
+			t.Errorf("greedy with %+v held %s of %s", rules, formatDice(keptDice(hand, roll.Keep)), formatDice(hand[:]))
+		}
+	}
+}
+
+func TestRandomBotsAreSeededBySeat(t *testing.T) {
+	lineup, _ := ParseLineup("ai:random,ai:random")
+	state := func(g *Game, seat int) uint64 {
+		return (*g.Players[seat]).(*Bot).randomState()
+	}
+	first, second := NewGame(lineup, 1, Rules{}, nil), NewGame(lineup, 2, Rules{}, nil)
+	if state(first, 0) == state(first, 1) {
+		t.Error("both seats make the same random choices")
+	}
+	if state(first, 0) == state(second, 0) {
+		t.Error("a seat makes the same random choices in every game")
+	}
+	if again := NewGame(lineup, 1, Rules{}, nil); state(again, 1) != state(first, 1) {
+		t.Error("the same seed and seat should make the same choices")
+	}
+}
+
+func TestLoadAITuning(t *testing.T) {
+	original := botStyles["bonus-chaser"].tuning
+	dir := t.TempDir()
+	write := func(name, config string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tunings, err := LoadAITuning(write("good.json", `{"bonus-chaser": {"upper_point_worth": 2}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lineup, _ := ParseLineup("ai:bonus-chaser,ai:optimal")
+	tuned, err := tunings.Apply(lineup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	players := NewPlayers(tuned, Rules{}, nil)
+	bot := (*players[0]).(*Bot)
+	if bot.Tuning.UpperPointWorth != 2 || bot.Tuning.UpperBonusWorth != original.UpperBonusWorth {
+		t.Errorf("expected only upper_point_worth to change, got %+v", bot.Tuning)
+	}
+	if other := (*players[1]).(*Bot); other.Tuning != DefaultTuning {
+		t.Errorf("expected optimal to keep its tuning, got %+v", other.Tuning)
+	}
+	if lineup[0].Tuning != nil || botStyles["bonus-chaser"].tuning != original {
+		t.Error("applying the tuning changed the lineup or the default")
+	}
+	if _, err := (AITunings{"greedy": DefaultTuning}).Apply(lineup); err == nil {
+		t.Error("expected an error tuning greedy, which has no tuning")
+	}
+
+	for _, config := range []string{
+		`{"greedy": {}}`,
+		`{"psychic": {}}`,
+		`{"bonus-chaser": {"upper_point_worth": 3}, "optimal": {"luck": 1}}`,
+		`not json`,
+	} {
+		if _, err := LoadAITuning(write("bad.json", config)); err == nil {
+			t.Errorf("expected an error loading %s", config)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package yahtzee
+
+// Category is a scorecard row a roll can be scored in
+type Category int
+
+const (
+	OnesCategory Category = iota
+	TwosCategory
+	ThreesCategory
+	FoursCategory
+	FivesCategory
+	SixesCategory
+	ThreeOfAKindCategory
+	FourOfAKindCategory
+	FullHouseCategory
+	SmallStraightCategory
+	LargeStraightCategory
+	ChanceCategory
+	YahtzeeCategory
+
+	// CategoryCount is the number of categories, and so of turns in a game
+	CategoryCount
+)
+
+var categoryNames = [CategoryCount]ScorableName{
+	OnesName, TwosName, ThreesName, FoursName, FivesName, SixesName,
+	ThreeOfAKindName, FourOfAKindName, FullHouseName, SmallStraightName, LargeStraightName, ChanceName, YahtzeeName,
+}
+
+var categoryScoreables = [CategoryCount]Scoreable{
+	Ones{}, Twos{}, Threes{}, Fours{}, Fives{}, Sixes{},
+	ThreeOfAKind{}, FourOfAKind{}, FullHouse{}, SmallStraight{}, LargeStraight{}, Chance{}, Yahtzee{},
+}
+
+var categoryVarieties = [CategoryCount]ScorableVariety{
+	FaceValueVariety, FaceValueVariety, FaceValueVariety, FaceValueVariety, FaceValueVariety, FaceValueVariety,
+	OfAKindVariety, OfAKindVariety, FullHouseVariety, StraightVariety, StraightVariety, ChanceVariety, OfAKindVariety,
+}
+
+// Categories lists every category in scorecard order
+func Categories() []Category {
+	categories := make([]Category, CategoryCount)
+	for idx := range categories {
+		categories[idx] = Category(idx)
+	}
+	return categories
+}
+
+// Valid reports whether c is one of the categories
+func (c Category) Valid() bool {
+	return c >= 0 && c < CategoryCount
+}
+
+// Name is the category's name as printed on the scorecard
+func (c Category) Name() ScorableName {
+	if !c.Valid() {
+		return ErrorName
+	}
+	return categoryNames[c]
+}
+
+func (c Category) String() string {
+	return string(c.Name())
+}
+
+// Scoreable scores rolls for the category
+func (c Category) Scoreable() Scoreable {
+	return categoryScoreables[c]
+}
+
+// Variety groups categories that are chased the same way
+func (c Category) Variety() ScorableVariety {
+	return categoryVarieties[c]
+}
+
+// Upper reports whether the category counts towards the upper section bonus
+func (c Category) Upper() bool {
+	return c <= SixesCategory
+}
+
+// Face is the die value an upper section category counts, 0 for the others
+func (c Category) Face() int {
+	if !c.Upper() {
+		return 0
+	}
+	return int(c-OnesCategory) + 1
+}
+
+// CategoryByName finds the category printed with the name
+func CategoryByName(name ScorableName) (Category, bool) {
+	for idx, categoryName := range categoryNames {
+		if categoryName == name {
+			return Category(idx), true
+		}
+	}
+	return 0, false
+}
+
+func (s Ones) Category() Category          { return OnesCategory }
+func (s Twos) Category() Category          { return TwosCategory }
+func (s Threes) Category() Category        { return ThreesCategory }
+func (s Fours) Category() Category         { return FoursCategory }
+func (s Fives) Category() Category         { return FivesCategory }
+func (s Sixes) Category() Category         { return SixesCategory }
+func (s ThreeOfAKind) Category() Category  { return ThreeOfAKindCategory }
+func (s FourOfAKind) Category() Category   { return FourOfAKindCategory }
+func (s FullHouse) Category() Category     { return FullHouseCategory }
+func (s SmallStraight) Category() Category { return SmallStraightCategory }
+func (s LargeStraight) Category() Category { return LargeStraightCategory }
+func (s Chance) Category() Category        { return ChanceCategory }
+func (s Yahtzee) Category() Category       { return YahtzeeCategory }
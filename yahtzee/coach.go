@@ -0,0 +1,144 @@
+package yahtzee
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// CoachTolerance is the fewest expected points a choice has to lose before
+// the coach mentions it
+const CoachTolerance = 0.5
+
+// Mistake is a choice that gave up expected points
+type Mistake struct {
+	Hand Hand
+	// RollsRemaining is 0 for choosing a category to score in
+	RollsRemaining int
+	Chosen         string
+	Best           string
+	// Loss is the expected points given up
+	Loss float64
+}
+
+func (m Mistake) String() string {
+	if m.RollsRemaining == 0 {
+		return fmt.Sprintf("%s: scored %s instead of %s, about %.1f points lost",
+			formatDice(m.Hand[:]), m.Chosen, m.Best, m.Loss)
+	}
+	return fmt.Sprintf("%s: held %s instead of %s, about %.1f points lost",
+		formatDice(m.Hand[:]), m.Chosen, m.Best, m.Loss)
+}
+
+// Reviewer is a player with something to say once the game is over
+type Reviewer interface {
+	WriteReview(w io.Writer)
+}
+
+// Coach wraps a player, usually a human, and after each of their decisions
+// says how many expected points it lost against the best play
+type Coach struct {
+	Player
+	Rules Rules
+	// Log is where advice goes after each decision
+	Log      io.Writer
+	Mistakes []Mistake
+	// Decisions counts every choice the coach has judged
+	Decisions int
+}
+
+// NewCoach wraps the player in a coach that advises on log
+func NewCoach(player Player, rules Rules, log io.Writer) *Player {
+	p := Player(&Coach{Player: player, Rules: rules, Log: log})
+	return &p
+}
+
+func (c *Coach) analysis() *Analysis {
+	return NewAnalysis(c.GetScorecard(), c.Rules)
+}
+
+func (c *Coach) AssessRoll(hand Hand, rollsRemaining int) RollDecision {
+	keep := c.Player.AssessRoll(hand, rollsRemaining)
+
+	analysis := c.analysis()
+	best, bestValue := analysis.BestKeep(hand, rollsRemaining)
+	loss := bestValue - analysis.KeepValue(hand, keep, rollsRemaining)
+	c.judge(Mistake{
+		Hand:           hand,
+		RollsRemaining: rollsRemaining,
+		Chosen:         formatDice(keptDice(hand, keep)),
+		Best:           formatDice(keptDice(hand, best)),
+		Loss:           loss,
+	})
+	return keep
+}
+
+func (c *Coach) PickScorable(hand Hand) Scoreable {
+	scorable := c.Player.PickScorable(hand)
+
+	analysis := c.analysis()
+	best, bestValue := analysis.BestCategory(hand)
+	loss := bestValue - analysis.CategoryValue(scorable.Category(), hand)
+	c.judge(Mistake{
+		Hand:   hand,
+		Chosen: scorable.Category().String(),
+		Best:   best.String(),
+		Loss:   loss,
+	})
+	return scorable
+}
+
+// judge records and reports a decision if it lost enough to matter
+func (c *Coach) judge(decision Mistake) {
+	c.Decisions++
+	if decision.Loss < CoachTolerance {
+		return
+	}
+	c.Mistakes = append(c.Mistakes, decision)
+	if c.Log != nil {
+		fmt.Fprintf(c.Log, "Coach: %s\n", decision)
+	}
+}
+
+// Lost is the total expected points given up over the game
+func (c *Coach) Lost() float64 {
+	lost := 0.0
+	for _, mistake := range c.Mistakes {
+		lost += mistake.Loss
+	}
+	return lost
+}
+
+// Costliest are the n mistakes that lost the most, worst first
+func (c *Coach) Costliest(n int) []Mistake {
+	mistakes := append([]Mistake{}, c.Mistakes...)
+	sort.SliceStable(mistakes, func(i, j int) bool {
+		return mistakes[i].Loss > mistakes[j].Loss
+	})
+	if len(mistakes) > n {
+		mistakes = mistakes[:n]
+	}
+	return mistakes
+}
+
+// WriteReview sums up the game's mistakes, costliest first
+func (c *Coach) WriteReview(w io.Writer) {
+	if len(c.Mistakes) == 0 {
+		fmt.Fprintf(w, "Coach: %s played all %d decisions well\n", c.GetName(), c.Decisions)
+		return
+	}
+	fmt.Fprintf(w, "Coach: %s gave up about %.1f expected points in %d of %d decisions. The costliest:\n",
+		c.GetName(), c.Lost(), len(c.Mistakes), c.Decisions)
+	for idx, mistake := range c.Costliest(3) {
+		fmt.Fprintf(w, "  %d. %s\n", idx+1, mistake)
+	}
+}
+
+// CoachHumans puts a coach beside every human player, advising on log
+func (g *Game) CoachHumans(log io.Writer) {
+	for seat, spec := range g.Lineup {
+		if spec.Kind == HumanKind && seat < len(g.Players) {
+			g.Players[seat] = NewCoach(*g.Players[seat], g.Rules, log)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package yahtzee
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// scriptedPlayer holds nothing and scores every hand in the first open
+// category, a poor player for the coach to judge
+type scriptedPlayer struct {
+	card *Scorecard
+}
+
+func (p scriptedPlayer) GetName() string          { return "Script" }
+func (p scriptedPlayer) GetScorecard() *Scorecard { return p.card }
+
+func (p scriptedPlayer) AssessRoll(hand Hand, rollsRemaining int) RollDecision {
+	return make(RollDecision, len(hand))
+}
+
+func (p scriptedPlayer) PickScorable(hand Hand) Scoreable {
+	for _, category := range Categories() {
+		if !p.card.Filled(category) {
+			return category.Scoreable()
+		}
+	}
+	return Chance{}
+}
+
+func TestAnalysisPrefersObviousPlays(t *testing.T) {
+	analysis := NewAnalysis(&Scorecard{}, Rules{})
+	if best, _ := analysis.BestCategory(Hand{5, 5, 5, 5, 5}); best != YahtzeeCategory {
+		t.Errorf("expected five fives to be a Yahtzee, got %s", best)
+	}
+	keep, _ := analysis.BestKeep(Hand{1, 2, 6, 6, 6}, 2)
+	if kept := formatDice(keptDice(Hand{1, 2, 6, 6, 6}, keep)); kept != "6 6 6" {
+		t.Errorf("expected to hold the sixes, held %s", kept)
+	}
+	hand := Hand{2, 3, 4, 5, 5}
+	if analysis.KeepValue(hand, RollDecision{true, true, true, true, false}, 1) <= analysis.KeepValue(hand, make(RollDecision, 5), 1) {
+		t.Error("holding a small straight should beat rerolling everything")
+	}
+}
+
+func TestCoachReportsLostPoints(t *testing.T) {
+	var log bytes.Buffer
+	coach := &Coach{Player: scriptedPlayer{&Scorecard{}}, Log: &log}
+
+	coach.AssessRoll(Hand{1, 6, 6, 6, 6}, 2)
+	coach.PickScorable(Hand{6, 6, 6, 6, 6})
+
+	if len(coach.Mistakes) != 2 || coach.Decisions != 2 {
+		t.Fatalf("expected 2 mistakes in 2 decisions, got %d in %d", len(coach.Mistakes), coach.Decisions)
+	}
+	if worst := coach.Costliest(1)[0]; worst.Chosen != "Ones" || worst.Best != "Yahtzee" {
+		t.Errorf("expected scoring a Yahtzee in Ones to be the costliest, got %s", worst)
+	}
+	if !strings.Contains(log.String(), "Coach: 1 6 6 6 6: held nothing instead of 6 6 6 6") {
+		t.Errorf("unexpected advice:\n%s", log.String())
+	}
+
+	var review bytes.Buffer
+	coach.WriteReview(&review)
+	for _, want := range []string{"Script gave up about", "in 2 of 2 decisions", "1. 6 6 6 6 6: scored Ones instead of Yahtzee"} {
+		if !strings.Contains(review.String(), want) {
+			t.Errorf("review is missing %q:\n%s", want, review.String())
+		}
+	}
+}
+
+func TestCoachStaysQuietAboutGoodPlay(t *testing.T) {
+	var log bytes.Buffer
+	coach := &Coach{Player: AIPlayer{Scorecard: &Scorecard{}}, Log: &log}
+	coach.PickScorable(Hand{2, 2, 3, 3, 3})
+
+	if len(coach.Mistakes) != 0 || log.Len() != 0 {
+		t.Errorf("a full house in Full House shouldn't be a mistake:\n%s", log.String())
+	}
+}
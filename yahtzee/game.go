@@ -2,7 +2,9 @@ package yahtzee
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
+	"os"
 	"sort"
 )
 
@@ -20,76 +22,178 @@ func (rd *RollDecision) WillKeepAll() bool {
 	return true
 }
 
+// Rules turn off optional scoring rules. The zero value is the official rules.
+type Rules struct {
+	// NoYahtzeeBonus drops the 100 points for every Yahtzee after the first
+	NoYahtzeeBonus bool
+	// NoJokers stops a Yahtzee after the first scoring as a full house or straight
+	NoJokers bool
+}
+
 type Game struct {
 	Players []*Player
-	Winner  []Player
-	Seed    int64
-	LogFn   func()
+	// Lineup describes the players so a saved game can seat them again
+	Lineup []PlayerSpec
+	Winner []Player
+	Seed   int64
+	Rules  Rules
+	// Turn counts the turns taken by all players so far
+	Turn int
+	// Output is where the board is printed each turn, os.Stdout if nil
+	Output io.Writer
+	// SavePath, if set, is where the game is saved after every turn
+	SavePath string
+	// SharedDice gives every seat its own dice started from Seed, so players
+	// who hold the same dice see the same rolls. Tournaments use it to take
+	// luck out of the comparison.
+	SharedDice bool
+	LogFn      func()
+
+	rng    *rand.Rand
+	source *rngSource
+	// seatRNGs and seatSources are each seat's dice when SharedDice is set
+	seatRNGs    []*rand.Rand
+	seatSources []*rngSource
+}
+
+// NewGame seats the lineup. AIs explain their choices to aiLog, which may be
+// nil.
+func NewGame(lineup []PlayerSpec, seed int64, rules Rules, aiLog io.Writer) *Game {
+	g := &Game{
+		Players: NewPlayers(lineup, rules, aiLog),
+		Lineup:  lineup,
+		Seed:    seed,
+		Rules:   rules,
+	}
+	g.rng, g.source = newRand(seed)
+	for seat, plyr := range g.Players {
+		if random, ok := (*plyr).(randomPlayer); ok {
+			random.seedRandom(seatSeed(seed, seat))
+		}
+	}
+	return g
+}
+
+// Turns is how many turns the whole game takes
+func (g *Game) Turns() int {
+	return ScoreableCount * len(g.Players)
+}
+
+// Over reports whether every player has filled their scorecard
+func (g *Game) Over() bool {
+	return g.Turn >= g.Turns()
+}
+
+func (g *Game) out() io.Writer {
+	if g.Output == nil {
+		return os.Stdout
+	}
+	return g.Output
 }
 
-func (g *Game) getRoll(hand Hand, rd RollDecision) Hand {
+func (g *Game) getRoll(seat int, hand Hand, rd RollDecision) Hand {
 	retSlice := make([]int, 5)
 	for idx, keep := range rd {
 		if keep {
 			retSlice[idx] = hand[idx]
 		} else {
-			retSlice[idx] = rollDie()
+			retSlice[idx] = g.rollDie(seat)
 		}
 	}
 	sort.Ints(retSlice)
 	return Hand{retSlice[0], retSlice[1], retSlice[2], retSlice[3], retSlice[4]}
 }
 
-func rollDie() int {
-	return rand.Intn(6) + 1
+func (g *Game) rollDie(seat int) int {
+	if g.SharedDice {
+		g.seedSeats()
+		return g.seatRNGs[seat].Intn(6) + 1
+	}
+	// games built without NewGame start their dice from Seed
+	if g.rng == nil {
+		g.rng, g.source = newRand(g.Seed)
+	}
+	return g.rng.Intn(6) + 1
 }
 
+// seedSeats starts every seat's dice from Seed, if they aren't already
+func (g *Game) seedSeats() {
+	for len(g.seatRNGs) < len(g.Players) {
+		rng, source := newRand(g.Seed)
+		g.seatRNGs = append(g.seatRNGs, rng)
+		g.seatSources = append(g.seatSources, source)
+	}
+}
+
+// Play takes turns until the game is over, carrying on from the current turn
+// for a resumed game, then prints the final standings and any reviews
 func (g *Game) Play() {
-	rand.Seed(g.Seed)
-	for idx := 0; idx < ScoreableCount; idx++ {
-		for _, plyr := range g.Players {
-			g.playTurn(*plyr)
-		}
+	for !g.Over() {
+		g.takeTurn()
 	}
-	topScore := 0
-	for _, plyr := range g.Players {
-		total := (*plyr).GetScorecard().Total()
-		if total >= topScore {
-			topScore = total
-			g.Winner = []Player{*plyr}
-		} else if topScore == total {
-			g.Winner = append(g.Winner, *plyr)
+	g.Winner = Leaders(g.Standings())
+	if g.Output != io.Discard {
+		WriteSummary(g.out(), g.Players, IsTerminal(g.out()))
+		for _, plyr := range g.Players {
+			if reviewer, ok := (*plyr).(Reviewer); ok {
+				reviewer.WriteReview(g.out())
+			}
 		}
 	}
 }
 
-func (g *Game) playTurn(p Player) {
-	hSlice := []int{rollDie(), rollDie(), rollDie(), rollDie(), rollDie()}
+// takeTurn plays the next player's turn and saves the game
+func (g *Game) takeTurn() {
+	g.playTurn(g.Turn % len(g.Players))
+	g.Turn++
+	g.autosave()
+}
+
+func (g *Game) playTurn(seat int) {
+	p := *g.Players[seat]
+	hSlice := []int{g.rollDie(seat), g.rollDie(seat), g.rollDie(seat), g.rollDie(seat), g.rollDie(seat)}
 	sort.Ints(hSlice)
 	hand1 := Hand{hSlice[0], hSlice[1], hSlice[2], hSlice[3], hSlice[4]}
 	// hand1 := Hand{6, 6, 6, 6, 6}
 	rd1 := p.AssessRoll(hand1, 2)
 	if rd1.WillKeepAll() {
-		score(p, hand1)
+		g.score(seat, hand1)
 	} else {
-		hand2 := g.getRoll(hand1, rd1)
+		hand2 := g.getRoll(seat, hand1, rd1)
 		rd2 := p.AssessRoll(hand2, 1)
 
 		if rd2.WillKeepAll() {
-			score(p, hand2)
+			g.score(seat, hand2)
 		} else {
-			hand3 := g.getRoll(hand2, rd2)
-			score(p, hand3)
+			hand3 := g.getRoll(seat, hand2, rd2)
+			g.score(seat, hand3)
 		}
 	}
 }
 
-func score(p Player, hand Hand) {
-	// TOOD: This apparently allows doublepicking
-	scorable := p.PickScorable(hand)
-	scorecard := p.GetScorecard()
+func (g *Game) score(seat int, hand Hand) {
+	p := *g.Players[seat]
+	// drawing the board is wasted work when nobody will see it
+	if g.Output != io.Discard {
+		fmt.Fprintf(g.out(), "%s rolled %v\n", p.GetName(), hand)
+		board := Scoreboard{
+			Players: g.Players,
+			Current: seat,
+			Hand:    &hand,
+			Rules:   g.Rules,
+			Color:   IsTerminal(g.out()),
+		}
+		board.Write(g.out())
+	}
 
-	scorecard.Score(&hand, scorable)
-	fmt.Println(p.GetName())
-	fmt.Println(p.GetScorecard().Print())
+	// a row can't be scored twice, so a player who picks a filled one is
+	// asked again
+	for {
+		scorable := p.PickScorable(hand)
+		if _, err := p.GetScorecard().ScoreWithRules(&hand, scorable, g.Rules); err != nil {
+			fmt.Fprintf(g.out(), "%s: %v, pick another row\n", p.GetName(), err)
+			continue
+		}
+		return
+	}
 }
@@ -0,0 +1,139 @@
+package yahtzee
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	HumanKind = "human"
+	AIKind    = "ai"
+)
+
+// aiStrategies makes an AI player for each strategy name a lineup can ask for
+var aiStrategies = map[string]func(spec PlayerSpec, rules Rules, log io.Writer) *Player{
+	"greedy":           greedyPlayer,
+	"greedy-immediate": botPlayer,
+	"bonus-chaser":     botPlayer,
+	"yahtzee-hunter":   botPlayer,
+	"risk-averse":      botPlayer,
+	"optimal":          botPlayer,
+	"random":           botPlayer,
+}
+
+func greedyPlayer(spec PlayerSpec, rules Rules, log io.Writer) *Player {
+	p := Player(AIPlayer{Scorecard: &Scorecard{}, Name: spec.Name, Rules: rules, Log: log})
+	return &p
+}
+
+// AIStrategyNames lists the strategies an "ai:" spec can name
+func AIStrategyNames() []string {
+	names := make([]string, 0, len(aiStrategies))
+	for name := range aiStrategies {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PlayerSpec is one seat in a lineup
+type PlayerSpec struct {
+	Kind string
+	// Strategy is the AI strategy, empty for humans
+	Strategy string
+	Name     string
+	// Tuning, if set, replaces the AI strategy's own tuning
+	Tuning *Tuning
+}
+
+// ParseLineup parses a comma separated list of players like
+// "human:Alice,ai:greedy,ai:greedy:Robo". A human's detail is their name; an
+// AI's is its strategy, optionally followed by a name. Unnamed players get a
+// name from their kind, and repeated names are numbered so every seat can be
+// told apart.
+func ParseLineup(input string) ([]PlayerSpec, error) {
+	var specs []PlayerSpec
+	for _, field := range strings.Split(input, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			return nil, fmt.Errorf("empty player in lineup %q", input)
+		}
+		spec, err := parsePlayerSpec(field)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, spec)
+	}
+
+	seen := make(map[string]int, len(specs))
+	for _, spec := range specs {
+		seen[spec.Name]++
+	}
+	numbered := make(map[string]int, len(specs))
+	for idx, spec := range specs {
+		if seen[spec.Name] < 2 {
+			continue
+		}
+		// skip numbers that would clash with a name already in the lineup
+		name := spec.Name
+		for seen[name] > 0 {
+			numbered[spec.Name]++
+			name = spec.Name + " " + strconv.Itoa(numbered[spec.Name])
+		}
+		seen[name]++
+		specs[idx].Name = name
+	}
+	return specs, nil
+}
+
+func parsePlayerSpec(field string) (PlayerSpec, error) {
+	parts := strings.SplitN(field, ":", 3)
+	kind := strings.ToLower(parts[0])
+	switch kind {
+	case HumanKind:
+		if len(parts) > 2 {
+			return PlayerSpec{}, fmt.Errorf("%q: a human only takes a name, like human:Alice", field)
+		}
+		spec := PlayerSpec{Kind: HumanKind, Name: "Human"}
+		if len(parts) == 2 && parts[1] != "" {
+			spec.Name = parts[1]
+		}
+		return spec, nil
+	case AIKind:
+		spec := PlayerSpec{Kind: AIKind, Strategy: "greedy"}
+		if len(parts) > 1 && parts[1] != "" {
+			spec.Strategy = strings.ToLower(parts[1])
+		}
+		if _, ok := aiStrategies[spec.Strategy]; !ok {
+			return PlayerSpec{}, fmt.Errorf("%q: unknown AI strategy %q, pick one of %s",
+				field, spec.Strategy, strings.Join(AIStrategyNames(), ", "))
+		}
+		spec.Name = "🤖 " + spec.Strategy
+		if len(parts) == 3 && parts[2] != "" {
+			spec.Name = parts[2]
+		}
+		return spec, nil
+	}
+	return PlayerSpec{}, fmt.Errorf("%q: unknown player kind %q, use human or ai", field, parts[0])
+}
+
+// NewPlayer makes a player for the seat in a game with the rules. AIs
+// explain their choices to log, which may be nil.
+func (s PlayerSpec) NewPlayer(rules Rules, log io.Writer) *Player {
+	if s.Kind == HumanKind {
+		return NewHumanPlayer(s.Name, rules)
+	}
+	return aiStrategies[s.Strategy](s, rules, log)
+}
+
+// NewPlayers makes a player for every seat in the lineup
+func NewPlayers(specs []PlayerSpec, rules Rules, log io.Writer) []*Player {
+	players := make([]*Player, 0, len(specs))
+	for _, spec := range specs {
+		players = append(players, spec.NewPlayer(rules, log))
+	}
+	return players
+}
@@ -0,0 +1,64 @@
+package yahtzee
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseLineup(t *testing.T) {
+	tests := []struct {
+		input string
+		want  []PlayerSpec
+	}{
+		{"human:Alice,ai:greedy", []PlayerSpec{
+			{Kind: HumanKind, Name: "Alice"},
+			{Kind: AIKind, Strategy: "greedy", Name: "🤖 greedy"},
+		}},
+		{"human, AI:Greedy:Robo", []PlayerSpec{
+			{Kind: HumanKind, Name: "Human"},
+			{Kind: AIKind, Strategy: "greedy", Name: "Robo"},
+		}},
+		{"ai,ai,human:Bo", []PlayerSpec{
+			{Kind: AIKind, Strategy: "greedy", Name: "🤖 greedy 1"},
+			{Kind: AIKind, Strategy: "greedy", Name: "🤖 greedy 2"},
+			{Kind: HumanKind, Name: "Bo"},
+		}},
+		{"ai:greedy:Bot,ai:greedy:Bot,ai:greedy:Bot 1", []PlayerSpec{
+			{Kind: AIKind, Strategy: "greedy", Name: "Bot 2"},
+			{Kind: AIKind, Strategy: "greedy", Name: "Bot 3"},
+			{Kind: AIKind, Strategy: "greedy", Name: "Bot 1"},
+		}},
+		{"ai:optimal,human:Alice", []PlayerSpec{
+			{Kind: AIKind, Strategy: "optimal", Name: "🤖 optimal"},
+			{Kind: HumanKind, Name: "Alice"},
+		}},
+	}
+	for _, tt := range tests {
+		got, err := ParseLineup(tt.input)
+		if err != nil {
+			t.Errorf("ParseLineup(%q) error = %v", tt.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseLineup(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestParseLineupErrors(t *testing.T) {
+	for _, input := range []string{"", "human:Alice,", "robot", "ai:psychic", "human:Alice:Smith"} {
+		if _, err := ParseLineup(input); err == nil {
+			t.Errorf("ParseLineup(%q) should fail", input)
+		}
+	}
+}
+
+func TestNewPlayersUsesNames(t *testing.T) {
+	specs, _ := ParseLineup("human:Alice,ai:greedy:Robo")
+	players := NewPlayers(specs, Rules{}, nil)
+	for idx, want := range []string{"Alice", "Robo"} {
+		if got := (*players[idx]).GetName(); got != want {
+			t.Errorf("player %d is named %q, want %q", idx, got, want)
+		}
+	}
+}
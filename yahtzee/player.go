@@ -18,10 +18,16 @@ type Player interface {
 
 type HumanPlayer struct {
 	Scorecard *Scorecard
+	Name      string
+	// Rules decide what each row is shown to be worth
+	Rules Rules
 }
 
 func (p HumanPlayer) GetName() string {
-	return "Mr. Human!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!!"
+	if p.Name == "" {
+		return "Human"
+	}
+	return p.Name
 }
 
 func (p HumanPlayer) GetScorecard() *Scorecard {
@@ -30,7 +36,7 @@ func (p HumanPlayer) GetScorecard() *Scorecard {
 
 // TODO would be good to indicate roll no./rolls remaining
 func (p HumanPlayer) AssessRoll(hand Hand, rollsRemaining int) RollDecision {
-	fmt.Printf("Roll: %d, %d, %d, %d, %d, \n", hand[0], hand[1], hand[2], hand[3], hand[4])
+	fmt.Printf("%s, roll: %d, %d, %d, %d, %d, \n", p.GetName(), hand[0], hand[1], hand[2], hand[3], hand[4])
 	// fmt.Println("Type y to keep, space to reroll:")
 	reader := bufio.NewReader(os.Stdin)
 	allInts := regexp.MustCompile("[1-6]{1,5}")
@@ -104,35 +110,36 @@ func (p HumanPlayer) EnsureValidResponse(prompt string, isValid func(string) boo
 }
 
 func (p HumanPlayer) PickScorable(hand Hand) Scoreable {
-	fmt.Printf("Hand: %d, %d, %d, %d, %d, \n", hand[0], hand[1], hand[2], hand[3], hand[4])
-	prompt := "Choose a row to score this roll\n"
-	options := make(map[int]ScorableName)
-	promptForName := make(map[ScorableName]string)
-	for idx, name := range ScorableNames {
-		if p.Scorecard.NameToScorePtr(name) == nil {
-			options[idx+1] = name
-			score := ScoreableByName(name).Score(hand, p.Scorecard.HadYahztee())
-			promptForName[name] = fmt.Sprintf("(%2d points) [%d] to score %s;", score, idx+1, name)
-		}
-	}
-	prompt += p.Scorecard.PrintWithDecorator(func(name ScorableName) string {
-		return promptForName[name]
-	})
-	input := p.EnsureValidResponse(prompt, func(input string) bool {
+	fmt.Printf("%s, hand: %d, %d, %d, %d, %d, \n", p.GetName(), hand[0], hand[1], hand[2], hand[3], hand[4])
+	input := p.EnsureValidResponse(p.scoringPrompt(hand), func(input string) bool {
 		val, err := strconv.Atoi(input)
+		category := Category(val - 1)
 
-		return err == nil && val > 0 && val <= len(ScorableNames) && options[val] != ""
+		return err == nil && category.Valid() && !p.Scorecard.Filled(category)
 	})
 	choice, _ := strconv.Atoi(input)
 
-	return ScoreableByName(options[choice])
+	return Category(choice - 1).Scoreable()
+}
+
+// scoringPrompt lists the open rows by number with what the hand would
+// score in each, the same as the board's This roll column
+func (p HumanPlayer) scoringPrompt(hand Hand) string {
+	board := Scoreboard{Hand: &hand, Rules: p.Rules}
+	prompt := "Choose a row to score this roll:"
+	for _, line := range ScorecardLines {
+		if cell := board.potential(p.Scorecard, line); cell.text != "" {
+			prompt += fmt.Sprintf("\n  [%d] %s (%s points)", line.Category+1, line.Category, cell.text)
+		}
+	}
+	return prompt
 }
 
-func NewHumanPlayer() *Player {
-	z := 0
-	scoreCard := Scorecard{YahtzeeBonusName: &z}
+func NewHumanPlayer(name string, rules Rules) *Player {
 	hp := HumanPlayer{
-		Scorecard: &scoreCard,
+		Scorecard: &Scorecard{},
+		Name:      name,
+		Rules:     rules,
 	}
 
 	p := Player(hp)
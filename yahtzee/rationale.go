@@ -0,0 +1,175 @@
+package yahtzee
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// KeepCandidate is a category an AI considered chasing with a roll, and the
+// dice it would hold to chase it
+type KeepCandidate struct {
+	Category    Category
+	Keep        RollDecision
+	Probability float64
+	// Expected is the probability of hitting the category times its best score
+	Expected float64
+	// Weight is how much the AI wanted the category; the highest is chased
+	Weight float64
+}
+
+// RollRationale explains which dice an AI held and why
+type RollRationale struct {
+	Hand           Hand
+	RollsRemaining int
+	// Candidates are the open categories the AI weighed, in scorecard order
+	Candidates []KeepCandidate
+	// Target is the category being chased. It's Chance when no candidate was
+	// worth chasing.
+	Target Category
+	Keep   RollDecision
+}
+
+// ScoreCandidate is an open category an AI could score a hand in
+type ScoreCandidate struct {
+	Category Category
+	Score    int
+	// Bonus is extra weight for helping towards the upper section bonus
+	Bonus int
+}
+
+// Weight is how much the AI wanted to score in the category
+func (c ScoreCandidate) Weight() int {
+	return c.Score + c.Bonus
+}
+
+// ScoreRationale explains which category an AI scored a hand in and why
+type ScoreRationale struct {
+	Hand Hand
+	// Candidates are the open categories the AI weighed, in scorecard order
+	Candidates []ScoreCandidate
+	// Chosen is where the hand was scored. It's Chance when nothing else was
+	// worth taking.
+	Chosen Category
+}
+
+// keptDice lists the dice a decision holds
+func keptDice(hand Hand, keep RollDecision) []int {
+	kept := []int{}
+	for idx, die := range hand {
+		if idx < len(keep) && keep[idx] {
+			kept = append(kept, die)
+		}
+	}
+	return kept
+}
+
+func formatDice(dice []int) string {
+	if len(dice) == 0 {
+		return "nothing"
+	}
+	text := make([]string, len(dice))
+	for idx, die := range dice {
+		text[idx] = fmt.Sprint(die)
+	}
+	return strings.Join(text, " ")
+}
+
+// Summary is a one line account of the decision
+func (r RollRationale) Summary() string {
+	rolls := "rolls"
+	if r.RollsRemaining == 1 {
+		rolls = "roll"
+	}
+	return fmt.Sprintf("%s, %d %s left: chasing %s, holding %s",
+		formatDice(r.Hand[:]), r.RollsRemaining, rolls, r.Target, formatDice(keptDice(r.Hand, r.Keep)))
+}
+
+// String is the summary followed by every candidate, most wanted first
+func (r RollRationale) String() string {
+	candidates := append([]KeepCandidate{}, r.Candidates...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Weight > candidates[j].Weight
+	})
+
+	var out strings.Builder
+	fmt.Fprintln(&out, r.Summary())
+	for _, candidate := range candidates {
+		marker := " "
+		if candidate.Category == r.Target {
+			marker = ">"
+		}
+		fmt.Fprintf(&out, "  %s %-16s %3.0f%% to hit, expect %4.1f, hold %s\n", marker, candidate.Category,
+			candidate.Probability*100, candidate.Expected, formatDice(keptDice(r.Hand, candidate.Keep)))
+	}
+	return out.String()
+}
+
+// chosen is the candidate for the chosen category, and false when the hand
+// was thrown into Chance without weighing it
+func (r ScoreRationale) chosen() (ScoreCandidate, bool) {
+	for _, candidate := range r.Candidates {
+		if candidate.Category == r.Chosen {
+			return candidate, true
+		}
+	}
+	return ScoreCandidate{}, false
+}
+
+// RunnerUp is the best candidate that wasn't chosen, and false if there was
+// no alternative
+func (r ScoreRationale) RunnerUp() (ScoreCandidate, bool) {
+	var best ScoreCandidate
+	found := false
+	for _, candidate := range r.Candidates {
+		if candidate.Category == r.Chosen {
+			continue
+		}
+		if !found || candidate.Weight() > best.Weight() {
+			best, found = candidate, true
+		}
+	}
+	return best, found
+}
+
+// Reason says why the chosen category beat the alternatives
+func (r ScoreRationale) Reason() string {
+	chosen, weighed := r.chosen()
+	if !weighed {
+		return fmt.Sprintf("nothing else was worth taking, so it goes in %s", r.Chosen)
+	}
+	reason := fmt.Sprintf("%s scores %d", chosen.Category, chosen.Score)
+	if chosen.Bonus > 0 {
+		reason += fmt.Sprintf(" and is worth %d more towards the upper bonus", chosen.Bonus)
+	}
+	if runnerUp, ok := r.RunnerUp(); ok {
+		reason += fmt.Sprintf(", ahead of %s at %d", runnerUp.Category, runnerUp.Score)
+		if runnerUp.Bonus > 0 {
+			reason += fmt.Sprintf(" plus %d", runnerUp.Bonus)
+		}
+	}
+	return reason
+}
+
+// String is the reason followed by every candidate, best first
+func (r ScoreRationale) String() string {
+	candidates := append([]ScoreCandidate{}, r.Candidates...)
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Weight() > candidates[j].Weight()
+	})
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "%s: %s\n", formatDice(r.Hand[:]), r.Reason())
+	for _, candidate := range candidates {
+		marker := " "
+		if candidate.Category == r.Chosen {
+			marker = ">"
+		}
+		fmt.Fprintf(&out, "  %s %-16s %2d", marker, candidate.Category, candidate.Score)
+		if candidate.Bonus > 0 {
+			fmt.Fprintf(&out, " +%d bonus", candidate.Bonus)
+		}
+		fmt.Fprintln(&out)
+	}
+	return out.String()
+}
@@ -0,0 +1,66 @@
+package yahtzee
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExplainRollHoldsForTarget(t *testing.T) {
+	ai := AIPlayer{Scorecard: &Scorecard{}, Name: "Robo"}
+	ai.Scorecard.Set(SixesCategory, 18)
+	hand := Hand{1, 2, 6, 6, 6}
+
+	rationale := ai.ExplainRoll(hand, 2)
+	for _, candidate := range rationale.Candidates {
+		if candidate.Category == SixesCategory || candidate.Category == ChanceCategory {
+			t.Errorf("%s shouldn't be a candidate", candidate.Category)
+		}
+	}
+	if len(rationale.Candidates) != int(CategoryCount)-2 {
+		t.Errorf("expected %d candidates, got %d", CategoryCount-2, len(rationale.Candidates))
+	}
+	want := StrategyForScorable(rationale.Target).PickKeepers(hand)
+	if !reflect.DeepEqual(rationale.Keep, want) {
+		t.Errorf("holding %v while chasing %s, expected %v", rationale.Keep, rationale.Target, want)
+	}
+	if !reflect.DeepEqual(ai.AssessRoll(hand, 2), rationale.Keep) {
+		t.Error("AssessRoll disagrees with its rationale")
+	}
+}
+
+func TestExplainScoreSaysWhy(t *testing.T) {
+	ai := AIPlayer{Scorecard: &Scorecard{}, Name: "Robo"}
+	rationale := ai.ExplainScore(Hand{2, 2, 3, 3, 3})
+
+	if rationale.Chosen != FullHouseCategory {
+		t.Fatalf("expected Full House, got %s", rationale.Chosen)
+	}
+	runnerUp, ok := rationale.RunnerUp()
+	if !ok || runnerUp.Category != ThreeOfAKindCategory || runnerUp.Weight() != 13 {
+		t.Errorf("expected 3 Of A Kind worth 13 as the runner up, got %+v", runnerUp)
+	}
+	if reason := rationale.Reason(); reason != "Full House scores 25, ahead of 3 Of A Kind at 13" {
+		t.Errorf("unexpected reason %q", reason)
+	}
+}
+
+func TestAIExplainsOnlyToItsLog(t *testing.T) {
+	hand := Hand{2, 2, 3, 3, 3}
+	quiet := AIPlayer{Scorecard: &Scorecard{}, Name: "Quiet"}
+	quiet.AssessRoll(hand, 1)
+	quiet.PickScorable(hand)
+
+	var log bytes.Buffer
+	chatty := AIPlayer{Scorecard: &Scorecard{}, Name: "Chatty", Log: &log}
+	chatty.AssessRoll(hand, 1)
+	chatty.PickScorable(hand)
+
+	text := log.String()
+	for _, want := range []string{"Chatty: 2 2 3 3 3, 1 roll left: chasing", "Chatty: 2 2 3 3 3: Full House scores 25", "> Full House"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("log is missing %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package yahtzee
+
+import (
+	"math/rand"
+)
+
+// rngSource is a splitmix64 generator. Unlike the math/rand sources its whole
+// state is one number, so a game's dice can be saved and restored.
+type rngSource struct {
+	state uint64
+}
+
+func newRand(seed int64) (*rand.Rand, *rngSource) {
+	source := &rngSource{}
+	source.Seed(seed)
+	return rand.New(source), source
+}
+
+// seatSeed is the seed for a seat's own random choices in a game started
+// from seed. It's kept well apart from the dice and from every other seat.
+func seatSeed(seed int64, seat int) int64 {
+	mix := rngSource{state: uint64(seed) ^ uint64(seat+1)*0xd1342543de82ef95}
+	return int64(mix.Uint64())
+}
+
+func (s *rngSource) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+func (s *rngSource) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *rngSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
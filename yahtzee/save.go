@@ -0,0 +1,217 @@
+package yahtzee
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// SaveVersion is bumped whenever the save format changes incompatibly
+const SaveVersion = 1
+
+// savedGame is the on-disk form of a game
+type savedGame struct {
+	Version  int           `json:"version"`
+	Seed     int64         `json:"seed"`
+	RNGState uint64        `json:"rng_state"`
+	Rules    Rules         `json:"rules"`
+	Turn     int           `json:"turn"`
+	Players  []savedPlayer `json:"players"`
+	// SharedDice games save every seat's dice
+	SharedDice    bool     `json:"shared_dice,omitempty"`
+	SeatRNGStates []uint64 `json:"seat_rng_states,omitempty"`
+}
+
+type savedPlayer struct {
+	Kind      string     `json:"kind"`
+	Strategy  string     `json:"strategy,omitempty"`
+	Name      string     `json:"name"`
+	Tuning    *Tuning    `json:"tuning,omitempty"`
+	Scorecard *Scorecard `json:"scorecard"`
+	// RNGState is where an AI that makes random choices is up to
+	RNGState *uint64 `json:"rng_state,omitempty"`
+}
+
+// savedScorecard is a scorecard's JSON form. Scores are keyed by category
+// name and open categories are left out.
+type savedScorecard struct {
+	Scores       map[ScorableName]int `json:"scores"`
+	YahtzeeBonus int                  `json:"yahtzee_bonus"`
+}
+
+func (s *Scorecard) MarshalJSON() ([]byte, error) {
+	saved := savedScorecard{Scores: map[ScorableName]int{}, YahtzeeBonus: s.yahtzeeBonus}
+	for _, category := range Categories() {
+		if score, ok := s.Get(category); ok {
+			saved.Scores[category.Name()] = score
+		}
+	}
+	return json.Marshal(saved)
+}
+
+func (s *Scorecard) UnmarshalJSON(data []byte) error {
+	var saved savedScorecard
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return err
+	}
+	*s = Scorecard{yahtzeeBonus: saved.YahtzeeBonus}
+	for name, score := range saved.Scores {
+		category, ok := CategoryByName(name)
+		if !ok {
+			return fmt.Errorf("unknown scorecard category %q", name)
+		}
+		s.Set(category, score)
+	}
+	return nil
+}
+
+// DefaultSavePath returns where the CLI keeps its save file
+func DefaultSavePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "yahtzee_save.json"
+	}
+	return filepath.Join(dir, "yahtzee-cli", "save.json")
+}
+
+// MarshalGame encodes the complete state of a game between turns, including
+// the dice, so a loaded game rolls exactly as the original would
+func MarshalGame(g *Game) ([]byte, error) {
+	if len(g.Lineup) != len(g.Players) {
+		return nil, fmt.Errorf("the game has no lineup to save its players by")
+	}
+	if g.source == nil {
+		g.rng, g.source = newRand(g.Seed)
+	}
+	saved := savedGame{
+		Version:  SaveVersion,
+		Seed:     g.Seed,
+		RNGState: g.source.state,
+		Rules:    g.Rules,
+		Turn:     g.Turn,
+	}
+	if g.SharedDice {
+		g.seedSeats()
+		saved.SharedDice = true
+		for _, source := range g.seatSources {
+			saved.SeatRNGStates = append(saved.SeatRNGStates, source.state)
+		}
+	}
+	for seat, spec := range g.Lineup {
+		player := savedPlayer{
+			Kind:      spec.Kind,
+			Strategy:  spec.Strategy,
+			Name:      spec.Name,
+			Tuning:    spec.Tuning,
+			Scorecard: (*g.Players[seat]).GetScorecard(),
+		}
+		if random, ok := (*g.Players[seat]).(randomPlayer); ok {
+			state := random.randomState()
+			player.RNGState = &state
+		}
+		saved.Players = append(saved.Players, player)
+	}
+	return json.MarshalIndent(saved, "", "  ")
+}
+
+// UnmarshalGame restores a game encoded by MarshalGame. AIs explain their
+// choices to aiLog, which may be nil.
+func UnmarshalGame(data []byte, aiLog io.Writer) (*Game, error) {
+	var saved savedGame
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("reading save: %w", err)
+	}
+	if saved.Version != SaveVersion {
+		return nil, fmt.Errorf("save version %d is not supported (expected %d)", saved.Version, SaveVersion)
+	}
+
+	lineup := make([]PlayerSpec, 0, len(saved.Players))
+	for _, player := range saved.Players {
+		spec := PlayerSpec{Kind: player.Kind, Strategy: player.Strategy, Name: player.Name, Tuning: player.Tuning}
+		if spec.Kind != HumanKind && aiStrategies[spec.Strategy] == nil {
+			return nil, fmt.Errorf("save has a player %s of unknown kind %s:%s", spec.Name, spec.Kind, spec.Strategy)
+		}
+		if spec.Tuning != nil && !tunable(spec.Strategy) {
+			return nil, fmt.Errorf("save tunes %s, but %s can't be tuned", spec.Name, spec.Strategy)
+		}
+		lineup = append(lineup, spec)
+	}
+
+	g := NewGame(lineup, saved.Seed, saved.Rules, aiLog)
+	g.Turn = saved.Turn
+	g.source.state = saved.RNGState
+	if saved.SharedDice {
+		if len(saved.SeatRNGStates) != len(saved.Players) {
+			return nil, fmt.Errorf("save has dice for %d seats but %d players", len(saved.SeatRNGStates), len(saved.Players))
+		}
+		g.SharedDice = true
+		g.seedSeats()
+		for seat, state := range saved.SeatRNGStates {
+			g.seatSources[seat].state = state
+		}
+	}
+	for seat, player := range saved.Players {
+		if player.Scorecard != nil {
+			*(*g.Players[seat]).GetScorecard() = *player.Scorecard
+		}
+		if random, ok := (*g.Players[seat]).(randomPlayer); ok && player.RNGState != nil {
+			random.setRandomState(*player.RNGState)
+		}
+	}
+	return g, nil
+}
+
+// SaveGame writes the game to path, replacing any earlier save
+func SaveGame(g *Game, path string) error {
+	data, err := MarshalGame(g)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// Write to a temporary file first so a crash can't leave a half written save
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// LoadGame reads a game saved with SaveGame. Later saves go back to the same
+// path.
+func LoadGame(path string, aiLog io.Writer) (*Game, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	g, err := UnmarshalGame(data, aiLog)
+	if err != nil {
+		return nil, err
+	}
+	g.SavePath = path
+	return g, nil
+}
+
+// autosave saves the game to SavePath. Finished games stay saved so their
+// scorecards can still be exported.
+func (g *Game) autosave() {
+	if g.SavePath == "" {
+		return
+	}
+	if err := SaveGame(g, g.SavePath); err != nil {
+		fmt.Fprintln(g.out(), "Could not save the game:", err)
+	}
+}
+
+// WriteText writes the scorecards as plain text for sharing
+func (g *Game) WriteText(w io.Writer) {
+	fmt.Fprintf(w, "Yahtzee, seed %d, turn %d of %d\n", g.Seed, g.Turn, g.Turns())
+	board := Scoreboard{Players: g.Players, Current: -1}
+	io.WriteString(w, board.String())
+	if g.Over() {
+		fmt.Fprintln(w, Announcement(g.Standings()))
+	}
+}
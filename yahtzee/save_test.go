@@ -0,0 +1,184 @@
+package yahtzee
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestGame(t *testing.T, seed int64) *Game {
+	t.Helper()
+	lineup, err := ParseLineup("ai:greedy:Ann,ai:greedy:Bo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := NewGame(lineup, seed, Rules{NoJokers: true}, nil)
+	g.Output = io.Discard
+	return g
+}
+
+func scorecards(g *Game) []Scorecard {
+	cards := make([]Scorecard, 0, len(g.Players))
+	for _, plyr := range g.Players {
+		cards = append(cards, *(*plyr).GetScorecard())
+	}
+	return cards
+}
+
+func TestScorecardJSONRoundTrip(t *testing.T) {
+	var partial, full Scorecard
+	partial.Set(ThreesCategory, 9)
+	partial.Set(YahtzeeCategory, 0)
+	for _, category := range Categories() {
+		full.Set(category, int(category)*2)
+	}
+	full.SetYahtzeeBonus(200)
+
+	for _, card := range []Scorecard{{}, partial, full} {
+		data, err := card.MarshalJSON()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var loaded Scorecard
+		if err := loaded.UnmarshalJSON(data); err != nil {
+			t.Fatal(err)
+		}
+		if loaded != card {
+			t.Errorf("scorecard changed in a round trip:\n%s", data)
+		}
+	}
+
+	var bad Scorecard
+	if err := bad.UnmarshalJSON([]byte(`{"scores": {"Sevens": 7}}`)); err == nil {
+		t.Error("expected an error for an unknown category")
+	}
+}
+
+func TestSaveRoundTrip(t *testing.T) {
+	for _, sharedDice := range []bool{false, true} {
+		original := newTestGame(t, 11)
+		original.SharedDice = sharedDice
+		for turn := 0; turn < 9; turn++ {
+			original.takeTurn()
+		}
+
+		data, err := MarshalGame(original)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded, err := UnmarshalGame(data, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded.Output = io.Discard
+
+		if loaded.Turn != 9 || loaded.Rules != original.Rules || loaded.SharedDice != sharedDice ||
+			!reflect.DeepEqual(loaded.Lineup, original.Lineup) {
+			t.Fatalf("loaded game differs: turn %d, rules %+v, shared dice %v, lineup %v",
+				loaded.Turn, loaded.Rules, loaded.SharedDice, loaded.Lineup)
+		}
+		if !reflect.DeepEqual(scorecards(loaded), scorecards(original)) {
+			t.Fatal("scorecards changed in a round trip")
+		}
+
+		// the dice carry on from where they stopped, so both games finish alike
+		original.Play()
+		loaded.Play()
+		if !reflect.DeepEqual(scorecards(loaded), scorecards(original)) {
+			t.Errorf("the resumed game played out differently with shared dice %v", sharedDice)
+		}
+	}
+}
+
+func TestSaveKeepsTuning(t *testing.T) {
+	lineup, _ := ParseLineup("ai:bonus-chaser:Ann,ai:greedy:Bo")
+	tuned, err := AITunings{"bonus-chaser": {UpperPointWorth: 3, UpperBonusWorth: 2}}.Apply(lineup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := MarshalGame(NewGame(tuned, 4, Rules{}, nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := UnmarshalGame(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Lineup, tuned) {
+		t.Errorf("loaded lineup %+v, want %+v", loaded.Lineup, tuned)
+	}
+	if bot := (*loaded.Players[0]).(*Bot); bot.Tuning != *tuned[0].Tuning {
+		t.Errorf("loaded bot is tuned %+v, want %+v", bot.Tuning, *tuned[0].Tuning)
+	}
+}
+
+func TestSaveKeepsRandomBotsOnCourse(t *testing.T) {
+	lineup, _ := ParseLineup("ai:random:Ann,ai:random:Bo")
+	original := NewGame(lineup, 8, Rules{}, nil)
+	original.Output = io.Discard
+	for turn := 0; turn < 7; turn++ {
+		original.takeTurn()
+	}
+	data, err := MarshalGame(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := UnmarshalGame(data, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded.Output = io.Discard
+
+	original.Play()
+	loaded.Play()
+	if !reflect.DeepEqual(scorecards(loaded), scorecards(original)) {
+		t.Error("the resumed random bots played differently")
+	}
+}
+
+func TestLoadGameSavesToSamePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "save.json")
+	g := newTestGame(t, 3)
+	g.SavePath = path
+	g.takeTurn()
+
+	loaded, err := LoadGame(path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.SavePath != path || loaded.Turn != 1 {
+		t.Errorf("loaded game has path %q and turn %d", loaded.SavePath, loaded.Turn)
+	}
+}
+
+func TestUnmarshalGameRejectsOtherVersions(t *testing.T) {
+	if _, err := UnmarshalGame([]byte(`{"version": 99}`), nil); err == nil {
+		t.Error("expected an error for an unsupported version")
+	}
+	if _, err := UnmarshalGame([]byte(`{"version": 1, "players": [{"kind": "ai", "strategy": "psychic"}]}`), nil); err == nil {
+		t.Error("expected an error for an unknown AI strategy")
+	}
+	if _, err := UnmarshalGame([]byte(`{"version": 1, "players": [{"kind": "ai", "strategy": "greedy", "tuning": {}}]}`), nil); err == nil {
+		t.Error("expected an error for a tuned greedy AI")
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	g := newTestGame(t, 5)
+	g.Play()
+	var out bytes.Buffer
+	g.WriteText(&out)
+
+	text := out.String()
+	for _, want := range []string{"Yahtzee, seed 5, turn 26 of 26", "| Ann |", "Total", "points"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("export is missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "\x1b[") {
+		t.Error("export has ANSI escapes")
+	}
+}
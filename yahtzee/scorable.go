@@ -1,11 +1,12 @@
 package yahtzee
 
-// If we add more categories, increment this? yuck
-const ScoreableCount = 13
+// ScoreableCount is the number of rows a roll can be scored in
+const ScoreableCount = int(CategoryCount)
 
 type Hand [5]int
 
 type Scoreable interface {
+	Category() Category
 	Score(hand Hand, hadYahtzee bool) int
 	MaxPossible() int
 	ProbabilityToHit(hand Hand, rollsRemaining int) float64
@@ -145,8 +146,10 @@ func (s Yahtzee) Score(hand Hand, hadYahtzee bool) int {
 	return 0
 }
 
-func valueCounts(hand Hand) map[int]int {
-	valueCounts := make(map[int]int, 0)
+// valueCounts counts the dice showing each face, indexed by face. It's an
+// array rather than a map so scoring doesn't allocate.
+func valueCounts(hand Hand) [7]int {
+	var valueCounts [7]int
 
 	for _, value := range hand {
 		valueCounts[value] = valueCounts[value] + 1
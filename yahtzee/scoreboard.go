@@ -0,0 +1,184 @@
+package yahtzee
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	ansiReset     = "\x1b[0m"
+	ansiBold      = "\x1b[1m"
+	ansiDim       = "\x1b[2m"
+	ansiHighlight = "\x1b[1;33m"
+	ansiPotential = "\x1b[32m"
+)
+
+// Scoreboard draws every player's scorecard as a column of one table
+type Scoreboard struct {
+	Players []*Player
+	// Current is the seat whose turn it is, or -1 for nobody
+	Current int
+	// Hand, if set, adds a column with what it would score in each of the
+	// current player's open rows
+	Hand  *Hand
+	Rules Rules
+	// Color highlights with ANSI escapes instead of plain text markers
+	Color bool
+}
+
+// IsTerminal reports whether w is a terminal, so it can show ANSI color
+func IsTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// boardCell is a table cell with the ANSI style it's drawn in, if any
+type boardCell struct {
+	text  string
+	style string
+}
+
+func (b Scoreboard) showPotential() bool {
+	return b.Hand != nil && b.Current >= 0 && b.Current < len(b.Players)
+}
+
+// header is the row of player names. Without color the current player is
+// marked with an arrow.
+func (b Scoreboard) header() []boardCell {
+	row := []boardCell{{}}
+	for seat, plyr := range b.Players {
+		cell := boardCell{text: (*plyr).GetName(), style: ansiBold}
+		if seat == b.Current {
+			cell.style = ansiHighlight
+			if !b.Color {
+				cell.text = "> " + cell.text
+			}
+		}
+		row = append(row, cell)
+	}
+	if b.showPotential() {
+		row = append(row, boardCell{text: "This roll", style: ansiPotential})
+	}
+	return row
+}
+
+// rows are the scorecard rows followed by the totals
+func (b Scoreboard) rows() [][]boardCell {
+	var current *Scorecard
+	if b.showPotential() {
+		current = (*b.Players[b.Current]).GetScorecard()
+	}
+
+	lines := append(append([]Line{}, ScorecardLines...), TotalLine)
+	rows := make([][]boardCell, 0, len(lines))
+	for _, line := range lines {
+		row := []boardCell{{text: string(line.Name)}}
+		for seat, plyr := range b.Players {
+			cell := boardCell{text: scorecardCell((*plyr).GetScorecard(), line)}
+			if seat == b.Current {
+				cell.style = ansiHighlight
+			} else if cell.text == "-" {
+				cell.style = ansiDim
+			}
+			row = append(row, cell)
+		}
+		if current != nil {
+			row = append(row, b.potential(current, line))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// potential is what the hand would score in the row, blank if the row
+// can't take it
+func (b Scoreboard) potential(scorecard *Scorecard, line Line) boardCell {
+	if line.Derive != nil || scorecard.Filled(line.Category) {
+		return boardCell{}
+	}
+	score := line.Category.Scoreable().Score(*b.Hand, !b.Rules.NoJokers && scorecard.HadYahztee())
+	return boardCell{text: strconv.Itoa(score), style: ansiPotential}
+}
+
+// Write draws the table
+func (b Scoreboard) Write(w io.Writer) {
+	writeTable(w, append([][]boardCell{b.header()}, b.rows()...), b.Color)
+}
+
+// String is the plain text table
+func (b Scoreboard) String() string {
+	var out strings.Builder
+	b.Color = false
+	b.Write(&out)
+	return out.String()
+}
+
+// scorecardCell is the text for one line of a scorecard, "-" if it's open
+func scorecardCell(s *Scorecard, line Line) string {
+	if score, ok := line.Value(s); ok {
+		return strconv.Itoa(score)
+	}
+	return "-"
+}
+
+// writeTable writes rows with the first column left aligned and the rest
+// right aligned, each as wide as its widest cell. The first row is the
+// header. Cells are styled only if color is set.
+func writeTable(w io.Writer, table [][]boardCell, color bool) {
+	widths := make([]int, len(table[0]))
+	for _, row := range table {
+		for col, cell := range row {
+			if width := displayWidth(cell.text); width > widths[col] {
+				widths[col] = width
+			}
+		}
+	}
+
+	rule := "+"
+	for _, width := range widths {
+		rule += strings.Repeat("-", width+2) + "+"
+	}
+	fmt.Fprintln(w, rule)
+	for idx, row := range table {
+		line := "|"
+		for col, cell := range row {
+			pad := strings.Repeat(" ", widths[col]-displayWidth(cell.text))
+			text := cell.text + pad
+			if col > 0 {
+				text = pad + cell.text
+			}
+			if color && cell.style != "" {
+				text = cell.style + text + ansiReset
+			}
+			line += " " + text + " |"
+		}
+		fmt.Fprintln(w, line)
+		if idx == 0 {
+			fmt.Fprintln(w, rule)
+		}
+	}
+	fmt.Fprintln(w, rule)
+}
+
+// displayWidth is roughly how many terminal columns the text takes up.
+// Emoji take two and variation selectors none.
+func displayWidth(text string) int {
+	width := 0
+	for _, r := range text {
+		switch {
+		case r == '\ufe0f':
+		case r >= 0x1F300:
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
@@ -0,0 +1,80 @@
+package yahtzee
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestScoreboardPlain(t *testing.T) {
+	hand := Hand{3, 3, 3, 5, 5}
+	board := Scoreboard{
+		Players: []*Player{
+			playerWithScores("Ann", map[Category]int{ThreesCategory: 9}),
+			playerWithScores("Bo", map[Category]int{ChanceCategory: 22}),
+		},
+		Current: 0,
+		Hand:    &hand,
+	}
+	out := board.String()
+
+	for _, want := range []string{
+		"| > Ann | Bo | This roll |",
+		"| Threes         |     9 |  - |           |",
+		"| Full House     |     - |  - |        25 |",
+		"| Chance         |     - | 22 |        19 |",
+		"| Total          |     9 | 22 |           |",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("board is missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("plain board has ANSI escapes:\n%s", out)
+	}
+}
+
+func TestScoreboardColor(t *testing.T) {
+	board := Scoreboard{
+		Players: []*Player{playerWithScores("Ann", nil), playerWithScores("Bo", nil)},
+		Current: 1,
+		Color:   true,
+	}
+	var out bytes.Buffer
+	board.Write(&out)
+
+	if !strings.Contains(out.String(), ansiHighlight+"Bo"+ansiReset) {
+		t.Errorf("current player isn't highlighted:\n%q", out.String())
+	}
+	if strings.Contains(out.String(), "> Bo") || strings.Contains(out.String(), "This roll") {
+		t.Errorf("colored board without a hand should have no marker or roll column:\n%s", out.String())
+	}
+}
+
+func TestIsTerminal(t *testing.T) {
+	if IsTerminal(&bytes.Buffer{}) {
+		t.Error("a buffer isn't a terminal")
+	}
+}
+
+func TestHumanScoringPromptFollowsRules(t *testing.T) {
+	hand := Hand{4, 4, 4, 4, 4}
+	for _, tt := range []struct {
+		rules Rules
+		want  string
+	}{
+		{Rules{}, "[11] Large Straight (40 points)"},
+		{Rules{NoJokers: true}, "[11] Large Straight (0 points)"},
+	} {
+		human := (*NewHumanPlayer("Ann", tt.rules)).(HumanPlayer)
+		human.Scorecard.Set(YahtzeeCategory, 50)
+		human.Scorecard.Set(FoursCategory, 20)
+		prompt := human.scoringPrompt(hand)
+		if !strings.Contains(prompt, tt.want) {
+			t.Errorf("with %+v the prompt is missing %q:\n%s", tt.rules, tt.want, prompt)
+		}
+		if strings.Contains(prompt, "Fours") || strings.Contains(prompt, "Yahtzee") {
+			t.Errorf("the prompt offers filled rows:\n%s", prompt)
+		}
+	}
+}
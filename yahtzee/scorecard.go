@@ -15,29 +15,6 @@ const (
 	ChanceVariety    = "Chance"
 )
 
-func (name ScorableName) VarietyOfScorable() ScorableVariety {
-	mapping := map[ScorableName]ScorableVariety{
-		OnesName:   FaceValueVariety,
-		TwosName:   FaceValueVariety,
-		ThreesName: FaceValueVariety,
-		FoursName:  FaceValueVariety,
-		FivesName:  FaceValueVariety,
-		SixesName:  FaceValueVariety,
-
-		SmallStraightName: StraightVariety,
-		LargeStraightName: StraightVariety,
-
-		ThreeOfAKindName: OfAKindVariety,
-		FourOfAKindName:  OfAKindVariety,
-		YahtzeeName:      OfAKindVariety,
-
-		FullHouseName: FullHouseVariety,
-
-		ChanceName: ChanceVariety,
-	}
-	return mapping[name]
-}
-
 type ScorableName string
 
 const (
@@ -59,184 +36,177 @@ const (
 	ChanceName        = "Chance"
 	YahtzeeName       = "Yahtzee"
 	YahtzeeBonusName  = "Yahtzee Bonus"
+	TotalName         = "Total"
 
 	ErrorName = "error"
 )
 
-var ScorableNames = []ScorableName{
-	OnesName, TwosName, ThreesName, FoursName, FivesName, SixesName,
-	SubtotalName, BonusName,
-	ThreeOfAKindName, FourOfAKindName, FullHouseName, SmallStraightName, LargeStraightName, ChanceName, YahtzeeName,
-	// Well, this isn't actually _scorable_, you can't record it, so, hrm.
-	YahtzeeBonusName,
-}
-
-func ScoreableByName(name ScorableName) Scoreable {
-	scorablesByName := map[ScorableName]Scoreable{
-		OnesName:          Ones{},
-		TwosName:          Twos{},
-		ThreesName:        Threes{},
-		FoursName:         Fours{},
-		FivesName:         Fives{},
-		SixesName:         Sixes{},
-		ThreeOfAKindName:  ThreeOfAKind{},
-		FourOfAKindName:   FourOfAKind{},
-		FullHouseName:     FullHouse{},
-		SmallStraightName: SmallStraight{},
-		LargeStraightName: LargeStraight{},
-		ChanceName:        Chance{},
-		YahtzeeName:       Yahtzee{},
+// Line is a line of the printed scorecard. A line either shows what was
+// scored in its category, or is derived from the categories.
+type Line struct {
+	Name     ScorableName
+	Category Category
+	// Derive computes a derived line, it's nil for category lines
+	Derive func(*Scorecard) int
+}
+
+// Value is the line's score, and false for a category that's still open
+func (l Line) Value(s *Scorecard) (int, bool) {
+	if l.Derive != nil {
+		return l.Derive(s), true
 	}
+	return s.Get(l.Category)
+}
 
-	return scorablesByName[name]
+func categoryLine(c Category) Line {
+	return Line{Name: c.Name(), Category: c}
 }
 
-type Scorecard map[ScorableName]*int
+// ScorecardLines are the lines of a scorecard in printed order, without the
+// total
+var ScorecardLines = []Line{
+	categoryLine(OnesCategory), categoryLine(TwosCategory), categoryLine(ThreesCategory),
+	categoryLine(FoursCategory), categoryLine(FivesCategory), categoryLine(SixesCategory),
+	{Name: SubtotalName, Derive: (*Scorecard).Subtotal},
+	{Name: BonusName, Derive: (*Scorecard).UpperBonus},
+	categoryLine(ThreeOfAKindCategory), categoryLine(FourOfAKindCategory), categoryLine(FullHouseCategory),
+	categoryLine(SmallStraightCategory), categoryLine(LargeStraightCategory), categoryLine(ChanceCategory),
+	categoryLine(YahtzeeCategory),
+	// Well, this isn't actually _scorable_, you can't record it, so, hrm.
+	{Name: YahtzeeBonusName, Derive: (*Scorecard).YahtzeeBonus},
+}
 
-func (s *Scorecard) NameToScorePtr(name ScorableName) *int {
-	m := *s
-	sub := s.Subtotal()
-	// eh, fix this
-	bonus := sub
-	if sub > 63 {
-		bonus = sub + 25
-	}
+// TotalLine is the last line of a scorecard
+var TotalLine = Line{Name: TotalName, Derive: (*Scorecard).Total}
 
-	// consider constructing this with a loop
-	nameToPtr := map[ScorableName]*int{
-		OnesName:   m[OnesName],
-		TwosName:   m[TwosName],
-		ThreesName: m[ThreesName],
-		FoursName:  m[FoursName],
-		FivesName:  m[FivesName],
-		SixesName:  m[SixesName],
-
-		SubtotalName: &sub,
-		BonusName:    &bonus,
-
-		ThreeOfAKindName:  m[ThreeOfAKindName],
-		FourOfAKindName:   m[FourOfAKindName],
-		FullHouseName:     m[FullHouseName],
-		SmallStraightName: m[SmallStraightName],
-		LargeStraightName: m[LargeStraightName],
-		ChanceName:        m[ChanceName],
-		YahtzeeName:       m[YahtzeeName],
-		YahtzeeBonusName:  m[YahtzeeBonusName],
-	}
+// Scorecard holds what each category scored. The zero value is an empty
+// card.
+type Scorecard struct {
+	scores [CategoryCount]int
+	filled [CategoryCount]bool
+	// yahtzeeBonus is the points earned for Yahtzees after the first
+	yahtzeeBonus int
+}
+
+// Get is what the category scored, and false if it's still open
+func (s *Scorecard) Get(c Category) (int, bool) {
+	return s.scores[c], s.filled[c]
+}
+
+// Filled reports whether the category has been scored
+func (s *Scorecard) Filled(c Category) bool {
+	return s.filled[c]
+}
 
-	return nameToPtr[name]
+// Set records a score in the category without checking it against a roll
+func (s *Scorecard) Set(c Category, score int) {
+	s.scores[c] = score
+	s.filled[c] = true
+}
+
+// SetYahtzeeBonus replaces the Yahtzee bonus points
+func (s *Scorecard) SetYahtzeeBonus(points int) {
+	s.yahtzeeBonus = points
+}
+
+// Open counts the categories still to be scored
+func (s *Scorecard) Open() int {
+	open := 0
+	for _, filled := range s.filled {
+		if !filled {
+			open++
+		}
+	}
+	return open
 }
 
 func (s *Scorecard) HadYahztee() bool {
-	yahtzeeScore := s.NameToScorePtr(YahtzeeName)
-	return yahtzeeScore != nil && *yahtzeeScore != 0
-}
-
-func (s *Scorecard) Score(hand *Hand, scoreable Scoreable) int {
-	sc := scoreable.Score(*hand, s.HadYahztee())
-
-	score := &sc
-	s.scoreYahtzeeBonus(*hand)
-	m := *s
-	// TODO I would love to get rid of the mapping here, somehow
-	switch scoreable.(type) {
-	case Ones:
-		m[OnesName] = score
-	case Twos:
-		m[TwosName] = score
-	case Threes:
-		m[ThreesName] = score
-	case Fours:
-		m[FoursName] = score
-	case Fives:
-		m[FivesName] = score
-	case Sixes:
-		m[SixesName] = score
-	case ThreeOfAKind:
-		m[ThreeOfAKindName] = score
-	case FourOfAKind:
-		m[FourOfAKindName] = score
-	case FullHouse:
-		m[FullHouseName] = score
-	case SmallStraight:
-		m[SmallStraightName] = score
-	case LargeStraight:
-		m[LargeStraightName] = score
-	case Chance:
-		m[ChanceName] = score
-	case Yahtzee:
-		m[YahtzeeName] = score
+	return s.filled[YahtzeeCategory] && s.scores[YahtzeeCategory] != 0
+}
+
+func (s *Scorecard) Score(hand *Hand, scoreable Scoreable) (int, error) {
+	return s.ScoreWithRules(hand, scoreable, Rules{})
+}
+
+// ScoreWithRules records the hand in the scoreable's row under the given
+// rules. A row can only be scored once, so a filled row is an error and the
+// scorecard is left as it was.
+func (s *Scorecard) ScoreWithRules(hand *Hand, scoreable Scoreable, rules Rules) (int, error) {
+	category := scoreable.Category()
+	if !category.Valid() {
+		return 0, fmt.Errorf("category %d isn't on the scorecard", int(category))
 	}
-	return *score
+	if s.Filled(category) {
+		return 0, fmt.Errorf("%s has already been scored", category)
+	}
+	score := scoreable.Score(*hand, !rules.NoJokers && s.HadYahztee())
+	if !rules.NoYahtzeeBonus {
+		s.scoreYahtzeeBonus(*hand)
+	}
+	s.Set(category, score)
+	return score, nil
 }
 
 func (s *Scorecard) Subtotal() int {
-	m := *s
-	return ValOrZero(m[OnesName]) + ValOrZero(m[TwosName]) + ValOrZero(m[ThreesName]) +
-		ValOrZero(m[FoursName]) + ValOrZero(m[FivesName]) + ValOrZero(m[SixesName])
+	total := 0
+	for c := OnesCategory; c <= SixesCategory; c++ {
+		total += s.scores[c]
+	}
+	return total
 }
 
-func ValOrZero(ptr *int) int {
-	if ptr == nil {
-		return 0
+const (
+	// UpperBonusThreshold is the upper section subtotal that earns the bonus
+	UpperBonusThreshold = 63
+	UpperBonusPoints    = 25
+	// YahtzeeBonusPoints are earned for every Yahtzee after the first
+	YahtzeeBonusPoints = 100
+)
+
+// UpperBonus is the bonus earned by the upper section, if any
+func (s *Scorecard) UpperBonus() int {
+	if s.Subtotal() >= UpperBonusThreshold {
+		return UpperBonusPoints
 	}
-	return *ptr
+	return 0
+}
+
+// YahtzeeBonus is the total of the bonuses for Yahtzees after the first
+func (s *Scorecard) YahtzeeBonus() int {
+	return s.yahtzeeBonus
 }
 
 func (s *Scorecard) Total() int {
-	sub := s.Subtotal()
-	total := sub
-	if sub >= 63 {
-		total = total + 25
+	total := s.UpperBonus() + s.yahtzeeBonus
+	for _, score := range s.scores {
+		total += score
 	}
-	m := *s
-	return total + ValOrZero(m[ThreeOfAKindName]) + ValOrZero(m[FourOfAKindName]) + ValOrZero(m[FullHouseName]) +
-		ValOrZero(m[SmallStraightName]) + ValOrZero(m[LargeStraightName]) + ValOrZero(m[ChanceName]) + ValOrZero(m[YahtzeeName]) + ValOrZero((m[YahtzeeBonusName]))
+	return total
 }
 
 func (s *Scorecard) scoreYahtzeeBonus(hand Hand) int {
-	m := *s
-	if m[YahtzeeName] == nil || *m[YahtzeeName] == 0 {
-		return 0
-	}
-	for _, count := range valueCounts(hand) {
-		if count == 5 {
-			val := 100
-			if m[YahtzeeBonusName] != nil {
-				val = *m[YahtzeeBonusName] + 100
-			}
-			m[YahtzeeBonusName] = &val
-		}
+	if s.HadYahztee() && isYahtzee(hand) {
+		s.yahtzeeBonus += YahtzeeBonusPoints
 	}
-	return ValOrZero(m[YahtzeeBonusName])
+	return s.yahtzeeBonus
 }
 
 func (s *Scorecard) Print() string {
-	return s.PrintWithDecorator(func(ScorableName) string { return "" })
+	return s.PrintWithDecorator(func(Line) string { return "" })
 }
 
-func (s *Scorecard) PrintWithDecorator(decFn func(ScorableName) string) string {
+func (s *Scorecard) PrintWithDecorator(decFn func(Line) string) string {
 	str := "-------------------------------------\n"
 	str += "| name                         score|\n"
-	m := (*s)
-	for _, name := range ScorableNames {
-		valPtr := m[name]
+	for _, line := range ScorecardLines {
 		val := "-"
-		if name == SubtotalName {
-			val = strconv.Itoa(s.Subtotal())
-		} else if name == BonusName {
-			if s.Subtotal() >= 63 { //TODO extract this
-				val = "25"
-			} else {
-				val = "0"
-			}
-		} else if valPtr != nil {
-			val = strconv.Itoa(*valPtr)
+		if score, ok := line.Value(s); ok {
+			val = strconv.Itoa(score)
 		}
-		str += fmt.Sprintf("| %-14s                 %3s|", name, val)
-		str += decFn(name) + "\n"
+		str += fmt.Sprintf("| %-14s                 %3s|", line.Name, val)
+		str += decFn(line) + "\n"
 	}
-	str += fmt.Sprintf("| %-14s                 %3d|\n", "Total", s.Total())
+	str += fmt.Sprintf("| %-14s                 %3d|\n", TotalName, s.Total())
 	str += "-------------------------------------\n"
 	return str
 }
@@ -0,0 +1,99 @@
+package yahtzee
+
+import "testing"
+
+func TestScoreableCategories(t *testing.T) {
+	for _, category := range Categories() {
+		if got := category.Scoreable().Category(); got != category {
+			t.Errorf("%s's scoreable reports category %s", category, got)
+		}
+		if found, ok := CategoryByName(category.Name()); !ok || found != category {
+			t.Errorf("CategoryByName(%q) = %s, %v", category.Name(), found, ok)
+		}
+	}
+	if FoursCategory.Face() != 4 || ChanceCategory.Face() != 0 {
+		t.Error("only upper section categories have a face")
+	}
+}
+
+func TestScorecardDerivedLines(t *testing.T) {
+	var card Scorecard
+	hand := Hand{6, 6, 6, 6, 6}
+	card.Score(&hand, Yahtzee{})
+	card.Score(&hand, Sixes{})
+	hand = Hand{5, 5, 5, 5, 1}
+	card.Score(&hand, Fives{})
+	hand = Hand{4, 4, 4, 4, 2}
+	card.Score(&hand, Fours{})
+
+	if score, ok := card.Get(SixesCategory); !ok || score != 30 {
+		t.Errorf("Sixes = %d, %v, want 30", score, ok)
+	}
+	if _, ok := card.Get(OnesCategory); ok {
+		t.Error("Ones should still be open")
+	}
+	if card.Open() != int(CategoryCount)-4 {
+		t.Errorf("expected %d open categories, got %d", int(CategoryCount)-4, card.Open())
+	}
+
+	want := map[ScorableName]int{
+		SubtotalName:     66,
+		BonusName:        UpperBonusPoints,
+		YahtzeeBonusName: YahtzeeBonusPoints,
+		TotalName:        66 + UpperBonusPoints + 50 + YahtzeeBonusPoints,
+	}
+	for _, line := range append(ScorecardLines, TotalLine) {
+		if line.Derive == nil {
+			continue
+		}
+		if got, _ := line.Value(&card); got != want[line.Name] {
+			t.Errorf("%s = %d, want %d", line.Name, got, want[line.Name])
+		}
+	}
+}
+
+func TestScoringAFilledRowFails(t *testing.T) {
+	var card Scorecard
+	hand := Hand{6, 6, 6, 6, 6}
+	card.Score(&hand, Yahtzee{})
+	hand = Hand{6, 6, 6, 2, 1}
+	card.Score(&hand, Sixes{})
+
+	hand = Hand{6, 6, 6, 6, 6}
+	if _, err := card.Score(&hand, Sixes{}); err == nil {
+		t.Error("Expected scoring Sixes twice to fail")
+	}
+	if score, _ := card.Get(SixesCategory); score != 18 {
+		t.Errorf("Sixes = %d, want the first score of 18", score)
+	}
+	if card.YahtzeeBonus() != 0 {
+		t.Errorf("Expected no Yahtzee bonus for a rejected score, got %d", card.YahtzeeBonus())
+	}
+}
+
+func TestScoringDoesNotAllocate(t *testing.T) {
+	hands := []Hand{{1, 2, 3, 4, 5}, {2, 2, 3, 3, 3}, {6, 6, 6, 6, 6}, {1, 1, 4, 5, 6}}
+	allocs := testing.AllocsPerRun(100, func() {
+		var card Scorecard
+		for idx, category := range categoryScoreables {
+			hand := hands[idx%len(hands)]
+			card.ScoreWithRules(&hand, category, Rules{})
+		}
+		_ = card.Total()
+	})
+	if allocs != 0 {
+		t.Errorf("scoring a card allocated %.0f times", allocs)
+	}
+}
+
+func BenchmarkScoreCard(b *testing.B) {
+	hands := []Hand{{1, 2, 3, 4, 5}, {2, 2, 3, 3, 3}, {6, 6, 6, 6, 6}, {1, 1, 4, 5, 6}}
+	for n := 0; n < b.N; n++ {
+		var card Scorecard
+		for idx, category := range categoryScoreables {
+			hand := hands[idx%len(hands)]
+			card.ScoreWithRules(&hand, category, Rules{})
+		}
+		_ = card.Total()
+	}
+}
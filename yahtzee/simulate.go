@@ -0,0 +1,89 @@
+package yahtzee
+
+import (
+	"fmt"
+	"io"
+)
+
+// Simulation plays AI-only games without printing them
+type Simulation struct {
+	// Players are the seats in every game, they must all be AIs
+	Players []PlayerSpec
+	Games   int
+	// Seed is the first game's seed, each later game uses the next one
+	Seed  int64
+	Rules Rules
+	// Tunings retune the players' strategies
+	Tunings AITunings
+}
+
+// SimulationResult collects the final score of every AI in every game
+type SimulationResult struct {
+	Games  int
+	Seed   int64
+	Scores []int
+	// Bonuses counts scorecards that earned the upper section bonus
+	Bonuses int
+	// Yahtzees counts scorecards with a Yahtzee scored
+	Yahtzees int
+}
+
+// Run plays every game and collects the results. It fails if the tunings
+// can't be applied to the players.
+func (s Simulation) Run() (SimulationResult, error) {
+	result := SimulationResult{Games: s.Games, Seed: s.Seed}
+	players, err := s.Tunings.Apply(s.Players)
+	if err != nil {
+		return result, err
+	}
+	for idx := 0; idx < s.Games; idx++ {
+		game := NewGame(players, s.Seed+int64(idx), s.Rules, nil)
+		game.Output = io.Discard
+		game.Play()
+
+		for _, plyr := range game.Players {
+			scorecard := (*plyr).GetScorecard()
+			result.Scores = append(result.Scores, scorecard.Total())
+			if scorecard.UpperBonus() > 0 {
+				result.Bonuses++
+			}
+			if scorecard.HadYahztee() {
+				result.Yahtzees++
+			}
+		}
+	}
+	return result, nil
+}
+
+// Mean is the average final score
+func (r SimulationResult) Mean() float64 {
+	if len(r.Scores) == 0 {
+		return 0
+	}
+	sum := 0
+	for _, score := range r.Scores {
+		sum += score
+	}
+	return float64(sum) / float64(len(r.Scores))
+}
+
+// Report writes the score range and how often the big rows were hit
+func (r SimulationResult) Report(w io.Writer) {
+	fmt.Fprintf(w, "%d games from seed %d, %d scorecards\n", r.Games, r.Seed, len(r.Scores))
+	if len(r.Scores) == 0 {
+		return
+	}
+	low, high := r.Scores[0], r.Scores[0]
+	for _, score := range r.Scores {
+		if score < low {
+			low = score
+		}
+		if score > high {
+			high = score
+		}
+	}
+	count := float64(len(r.Scores))
+	fmt.Fprintf(w, "Mean score: %.1f (low %d, high %d)\n", r.Mean(), low, high)
+	fmt.Fprintf(w, "Upper bonus: %5.1f%% (%d)\n", float64(r.Bonuses)/count*100, r.Bonuses)
+	fmt.Fprintf(w, "Yahtzee:     %5.1f%% (%d)\n", float64(r.Yahtzees)/count*100, r.Yahtzees)
+}
@@ -0,0 +1,42 @@
+package yahtzee
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSimulationIsReproducible(t *testing.T) {
+	players, err := ParseLineup("ai,ai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sim := Simulation{Players: players, Games: 3, Seed: 7}
+	first, err := sim.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, _ := sim.Run()
+
+	if len(first.Scores) != 6 {
+		t.Fatalf("expected 6 scorecards, got %d", len(first.Scores))
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("same seed gave different results: %v and %v", first, second)
+	}
+}
+
+func TestRulesWithoutYahtzeeBonus(t *testing.T) {
+	hand := Hand{4, 4, 4, 4, 4}
+	withBonus := Scorecard{}
+	without := Scorecard{}
+	for _, card := range []*Scorecard{&withBonus, &without} {
+		card.Score(&hand, Yahtzee{})
+	}
+
+	withBonus.ScoreWithRules(&hand, Fours{}, Rules{})
+	without.ScoreWithRules(&hand, Fours{}, Rules{NoYahtzeeBonus: true})
+
+	if got := withBonus.Total() - without.Total(); got != 100 {
+		t.Errorf("expected the bonus to be worth 100, got %d", got)
+	}
+}
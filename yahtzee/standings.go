@@ -0,0 +1,129 @@
+package yahtzee
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Standing is one player's place at the end of a game, or so far
+type Standing struct {
+	Player Player
+	// Seat is the player's position in the game's turn order
+	Seat int
+	// Rank is 1 for the leaders. Tied players share a rank and the next rank
+	// is skipped, so two leaders are followed by a 3rd place.
+	Rank          int
+	Total         int
+	UpperSubtotal int
+	UpperBonus    int
+	YahtzeeBonus  int
+}
+
+// Standings ranks the players by total, best first. Tied players keep their
+// seat order.
+func Standings(players []*Player) []Standing {
+	standings := make([]Standing, 0, len(players))
+	for seat, plyr := range players {
+		scorecard := (*plyr).GetScorecard()
+		standings = append(standings, Standing{
+			Player:        *plyr,
+			Seat:          seat,
+			Total:         scorecard.Total(),
+			UpperSubtotal: scorecard.Subtotal(),
+			UpperBonus:    scorecard.UpperBonus(),
+			YahtzeeBonus:  scorecard.YahtzeeBonus(),
+		})
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		return standings[i].Total > standings[j].Total
+	})
+	for idx := range standings {
+		if idx > 0 && standings[idx].Total == standings[idx-1].Total {
+			standings[idx].Rank = standings[idx-1].Rank
+		} else {
+			standings[idx].Rank = idx + 1
+		}
+	}
+	return standings
+}
+
+// Standings ranks the game's players
+func (g *Game) Standings() []Standing {
+	return Standings(g.Players)
+}
+
+// Leaders are the players ranked first, more than one if they tied
+func Leaders(standings []Standing) []Player {
+	var leaders []Player
+	for _, standing := range standings {
+		if standing.Rank == 1 {
+			leaders = append(leaders, standing.Player)
+		}
+	}
+	return leaders
+}
+
+// Announcement names the winner, or everyone who tied for the win
+func Announcement(standings []Standing) string {
+	leaders := Leaders(standings)
+	if len(leaders) == 0 {
+		return "Nobody played"
+	}
+	total := standings[0].Total
+	if len(leaders) == 1 {
+		return fmt.Sprintf("%s wins with %d points", leaders[0].GetName(), total)
+	}
+	names := make([]string, 0, len(leaders))
+	for _, leader := range leaders {
+		names = append(names, leader.GetName())
+	}
+	return fmt.Sprintf("%s tie with %d points", joinNames(names), total)
+}
+
+// joinNames lists names as "A, B and C"
+func joinNames(names []string) string {
+	if len(names) == 1 {
+		return names[0]
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// WriteSummary writes every player's scorecard side by side, their final
+// rank, and who won
+func WriteSummary(w io.Writer, players []*Player, color bool) {
+	standings := Standings(players)
+	rank := make([]int, len(players))
+	for _, standing := range standings {
+		rank[standing.Seat] = standing.Rank
+	}
+
+	board := Scoreboard{Players: players, Current: -1, Color: color}
+	ranks := []boardCell{{text: "Rank"}}
+	for seat := range players {
+		cell := boardCell{text: ordinal(rank[seat])}
+		if rank[seat] == 1 {
+			cell.style = ansiHighlight
+		}
+		ranks = append(ranks, cell)
+	}
+	table := append([][]boardCell{board.header()}, board.rows()...)
+	writeTable(w, append(table, ranks), color)
+	fmt.Fprintln(w, Announcement(standings))
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
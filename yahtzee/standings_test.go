@@ -0,0 +1,75 @@
+package yahtzee
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// playerWithScores makes an AI whose scorecard has the given rows filled
+func playerWithScores(name string, scores map[Category]int) *Player {
+	plyr := NewLoggingAiPlayer(name, nil)
+	scorecard := (*plyr).GetScorecard()
+	for category, score := range scores {
+		scorecard.Set(category, score)
+	}
+	return plyr
+}
+
+func TestStandingsRanksTies(t *testing.T) {
+	players := []*Player{
+		playerWithScores("Ann", map[Category]int{ChanceCategory: 20}),
+		playerWithScores("Bo", map[Category]int{ChanceCategory: 25}),
+		playerWithScores("Cy", map[Category]int{ChanceCategory: 25}),
+		playerWithScores("Di", map[Category]int{ChanceCategory: 10}),
+	}
+	standings := Standings(players)
+
+	wantNames := []string{"Bo", "Cy", "Ann", "Di"}
+	wantRanks := []int{1, 1, 3, 4}
+	for idx, standing := range standings {
+		if got := standing.Player.GetName(); got != wantNames[idx] {
+			t.Errorf("standing %d is %s, want %s", idx, got, wantNames[idx])
+		}
+		if standing.Rank != wantRanks[idx] {
+			t.Errorf("%s is ranked %d, want %d", standing.Player.GetName(), standing.Rank, wantRanks[idx])
+		}
+	}
+
+	if got := len(Leaders(standings)); got != 2 {
+		t.Errorf("expected 2 leaders, got %d", got)
+	}
+	if got, want := Announcement(standings), "Bo and Cy tie with 25 points"; got != want {
+		t.Errorf("Announcement() = %q, want %q", got, want)
+	}
+}
+
+func TestStandingsBreakdown(t *testing.T) {
+	players := []*Player{playerWithScores("Ann", map[Category]int{
+		FivesCategory: 25, SixesCategory: 30, FoursCategory: 8, YahtzeeCategory: 50,
+	})}
+	(*players[0]).GetScorecard().SetYahtzeeBonus(100)
+	standing := Standings(players)[0]
+
+	if standing.UpperSubtotal != 63 || standing.UpperBonus != UpperBonusPoints || standing.YahtzeeBonus != 100 {
+		t.Errorf("unexpected breakdown %+v", standing)
+	}
+	if want := 63 + UpperBonusPoints + 50 + 100; standing.Total != want {
+		t.Errorf("Total = %d, want %d", standing.Total, want)
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	players := []*Player{
+		playerWithScores("Ann", map[Category]int{ChanceCategory: 20}),
+		playerWithScores("Bo", map[Category]int{ChanceCategory: 25}),
+	}
+	var out bytes.Buffer
+	WriteSummary(&out, players, false)
+
+	for _, want := range []string{"| Ann |  Bo |", "| Chance         |  20 |  25 |", "| Rank           | 2nd | 1st |", "Bo wins with 25 points"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("summary is missing %q:\n%s", want, out.String())
+		}
+	}
+}
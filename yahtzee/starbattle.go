@@ -165,6 +165,11 @@ func (p *Puzzle) Segments() map[Color][]Cell {
 	return m
 }
 
+// GetCellColor returns the segment the cell at the given column and row belongs to
+func (p *Puzzle) GetCellColor(column string, row int) Color {
+	return p.Cells[column][row].Segment
+}
+
 func (p *Puzzle) ColumnNames() []string {
 	return letters[0:p.Width]
 }
@@ -362,7 +367,6 @@ func (p *Puzzle) Solved() bool {
 	// Check segments
 	for color := range p.Segments() {
 		if p.StarsPerSegment(color) != p.CorrectStarsPerArea {
-			fmt.Printf("incorrect number of stars in %s. found %d, need %d\n", color, p.StarsPerSegment(color), p.CorrectStarsPerArea)
 			return false
 		}
 	}
@@ -370,7 +374,6 @@ func (p *Puzzle) Solved() bool {
 	// Check rows
 	for idx := range p.Rows() {
 		if p.StarsPerRow(idx) != p.CorrectStarsPerArea {
-			fmt.Printf("incorrect number of stars in row %d. found %d, need %d\n", idx, p.StarsPerRow(idx), p.CorrectStarsPerArea)
 			return false
 		}
 	}
@@ -378,7 +381,6 @@ func (p *Puzzle) Solved() bool {
 	// Check columns
 	for letter := range p.Columns() {
 		if p.StarsPerColumn(letter) != p.CorrectStarsPerArea {
-			fmt.Printf("incorrect number of stars in column %s. found %d, need %d\n", letter, p.StarsPerColumn(letter), p.CorrectStarsPerArea)
 			return false
 		}
 	}
@@ -425,7 +427,7 @@ func (p *Puzzle) IsUnsolvable() bool {
 	for _, row := range p.Rows() {
 		availableCells := 0
 		for _, cell := range row {
-			if cell.State != Blocked {
+			if cell.State == Empty || cell.State == Starred {
 				availableCells++
 			}
 		}
@@ -437,7 +439,7 @@ func (p *Puzzle) IsUnsolvable() bool {
 	for _, col := range p.Columns() {
 		availableCells := 0
 		for _, cell := range col {
-			if cell.State != Blocked {
+			if cell.State == Empty || cell.State == Starred {
 				availableCells++
 			}
 		}
@@ -449,7 +451,7 @@ func (p *Puzzle) IsUnsolvable() bool {
 	for _, cells := range p.Segments() {
 		availableCells := 0
 		for _, cell := range cells {
-			if p.Cells[cell.Column][cell.Row].State != Blocked {
+			if state := p.Cells[cell.Column][cell.Row].State; state == Empty || state == Starred {
 				availableCells++
 			}
 		}
@@ -526,20 +528,27 @@ func Solve(puzzle Puzzle) (Puzzle, bool) {
 		return puzzle, false
 	}
 
-	if puzzle.Solved() {
-		return puzzle, true
-	}
-
+	// check for a broken puzzle first, Solved doesn't look at adjacent stars
 	if puzzle.IsUnsolvable() {
 		return puzzle, false
 	}
 
+	if puzzle.Solved() {
+		return puzzle, true
+	}
+
 	// Try to deduce first
 	deduced, err := puzzle.Deduce()
 	if err != nil {
 		return puzzle, false
 	}
 	puzzle = *deduced
+	if puzzle.IsUnsolvable() {
+		return puzzle, false
+	}
+	if puzzle.Solved() {
+		return puzzle, true
+	}
 
 	// Try to place stars systematically by segment
 	segments := getSortedSegments(puzzle)
@@ -591,17 +600,11 @@ func Solve(puzzle Puzzle) (Puzzle, bool) {
 			if success {
 				return solved, true
 			}
-
-			// If placing a star didn't work, try eliminating the cell
-			newPuzzle = puzzle.DeepCopy()
-			newPuzzle.Cells[cell.Column][cell.Row].State = Eliminated
-
-			// Recursively solve
-			solved, success = Solve(*newPuzzle)
-			if success {
-				return solved, true
-			}
 		}
+
+		// The segment needs a star and none of its cells can take one, so
+		// there's no point trying the other segments
+		return puzzle, false
 	}
 
 	return puzzle, false
@@ -722,8 +725,8 @@ func (p *Puzzle) Deduce() (*Puzzle, error) {
 			if stars == p.CorrectStarsPerArea {
 				// Mark all remaining empty cells as eliminated
 				for _, cell := range row {
-					if cell.State == Empty {
-						cell.State = Eliminated
+					if p.Cells[cell.Column][cell.Row].State == Empty {
+						p.Cells[cell.Column][cell.Row].State = Eliminated
 						changed = true
 					}
 				}
@@ -736,8 +739,8 @@ func (p *Puzzle) Deduce() (*Puzzle, error) {
 			if stars == p.CorrectStarsPerArea {
 				// Mark all remaining empty cells as eliminated
 				for _, cell := range p.Cells[col] {
-					if cell.State == Empty {
-						cell.State = Eliminated
+					if p.Cells[cell.Column][cell.Row].State == Empty {
+						p.Cells[cell.Column][cell.Row].State = Eliminated
 						changed = true
 					}
 				}
@@ -780,7 +783,8 @@ func (p *Puzzle) Deduce() (*Puzzle, error) {
 		// Apply forced star placement
 		for i := 0; i < p.Height; i++ {
 			for j := 0; j < p.Width; j++ {
-				if p.Cells[letters[j]][i].State == Empty {
+				// a star placed earlier in this pass may not have eliminated its neighbors yet
+				if p.Cells[letters[j]][i].State == Empty && !p.hasStarredNeighbor(i, j) {
 					// Check if this is the only possible position for a star in its row, column, or segment
 					row := p.Rows()[i]
 					col := p.Cells[letters[j]]
@@ -828,7 +832,7 @@ func (p *Puzzle) Deduce() (*Puzzle, error) {
 			for j := 0; j < p.Width; j++ {
 				if p.Cells[letters[j]][i].State == Empty {
 					// Check for patterns that force star placement or elimination
-					if p.isForceStarPattern(i, j) {
+					if p.isForceStarPattern(i, j) && !p.hasStarredNeighbor(i, j) {
 						p.Cells[letters[j]][i].State = Starred
 						changed = true
 					} else if p.isForceEliminationPattern(i, j) {
@@ -843,6 +847,20 @@ func (p *Puzzle) Deduce() (*Puzzle, error) {
 	return p, nil
 }
 
+// hasStarredNeighbor reports whether any of the eight cells around the cell is starred
+func (p *Puzzle) hasStarredNeighbor(row, col int) bool {
+	for _, offset := range [][2]int{{-1, -1}, {-1, 0}, {-1, 1}, {0, -1}, {0, 1}, {1, -1}, {1, 0}, {1, 1}} {
+		newRow := row + offset[0]
+		newCol := col + offset[1]
+		if newRow >= 0 && newRow < p.Height && newCol >= 0 && newCol < p.Width {
+			if p.Cells[letters[newCol]][newRow].State == Starred {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // isForceStarPattern checks if the cell must contain a star based on surrounding patterns
 func (p *Puzzle) isForceStarPattern(row, col int) bool {
 	// Check if this is the only possible position for a star in a 2x2 area
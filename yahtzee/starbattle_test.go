@@ -442,12 +442,23 @@ func TestAllConstraints(t *testing.T) {
 	t.Logf("Segments: %d, Rows: %d, Cols: %d, RowSegs: %d, ColSegs: %d",
 		segments, numRows, cols, rowSegs, colSegs)
 
+	// Row and column segment constraints are made for every segment that
+	// crosses the row or column, so count those crossings
+	rowSegments := make(map[RowSegmentConstraint]bool)
+	colSegments := make(map[ColumnSegmentConstraint]bool)
+	for _, col := range puzzle.ColumnNames() {
+		for _, cell := range puzzle.Cells[col] {
+			rowSegments[RowSegmentConstraint{Row: cell.Row, Segment: cell.Segment}] = true
+			colSegments[ColumnSegmentConstraint{Column: col, Segment: cell.Segment}] = true
+		}
+	}
+
 	// Verify we have the expected number of constraints
 	expectedConstraints := len(puzzle.Segments()) + // Segment constraints
 		puzzle.Height + // Row constraints
 		puzzle.Width + // Column constraints
-		puzzle.Height + // Row segment constraints (one per row)
-		puzzle.Width // Column segment constraints (one per column)
+		len(rowSegments) + // Row segment constraints
+		len(colSegments) // Column segment constraints
 
 	if len(constraints) != expectedConstraints {
 		t.Errorf("AllConstraints() returned %d constraints, want %d", len(constraints), expectedConstraints)
@@ -457,16 +468,16 @@ func TestAllConstraints(t *testing.T) {
 func TestSolve10x10Puzzle(t *testing.T) {
 	// Create a 10x10 puzzle with 2 stars per area
 	rows := []string{
-		"🟨🟨🟨🟦🟦🟦🟩🟩🟩🟩",
-		"🟨🟨🟨🟦🟦🟦🟩🟩🟩🟩",
-		"🟨🟨🟨🟦🟦🟦🟩🟩🟩🟩",
-		"🟥🟥🟥🟧🟧🟧🟪🟪🟪🟪",
-		"🟥🟥🟥🟧🟧🟧🟪🟪🟪🟪",
-		"🟥🟥🟥🟧🟧🟧🟪🟪🟪🟪",
-		"⬛⬛⬛⬜⬜⬜🟫🟫🟫🟫",
-		"⬛⬛⬛⬜⬜⬜🟫🟫🟫🟫",
-		"⬛⬛⬛⬜⬜⬜🟫🟫🟫🟫",
-		"🟫🟫🟫🟫🟫🟫🟫🟫🟫🟫",
+		"🟧🟧🟧🟧🟧🟥🟥🟥🟥🟥",
+		"🟧🟧🟧🟧🟧🟥🟥🟥🟨🟥",
+		"🟧🟩🟩🟧🟩🟨🟨🟨🟨🟨",
+		"🟦🟩🟩🟩🟩🟩🟨🟪🟨🟨",
+		"🟦🟦🟦🟦🟩🟪🟪🟪🟪🟪",
+		"🟦🟦🟦🟦🟪🟪🟪🟪🟫🟫",
+		"⬛⬛🟦🟦🟪⬜🟫🟫🟫🟫",
+		"⬛⬛⬛⬜⬜⬜⬜🟫🔴🟫",
+		"⬛⬛⬜⬜⬜⬜🔴🔴🔴🔴",
+		"⬛⬛⬛⬜🔴🔴🔴🔴🔴🔴",
 	}
 
 	puzzle, err := ParsePuzzle(rows, 2)
@@ -488,6 +499,14 @@ func TestSolve10x10Puzzle(t *testing.T) {
 		t.Error("Solution verification failed")
 		solved.Print("Invalid solution:")
 	}
+	// Solved doesn't look at neighbors, so check no two stars touch
+	for row := 0; row < solved.Height; row++ {
+		for col, letter := range solved.ColumnNames() {
+			if solved.Cells[letter][row].State == Starred && solved.hasStarredNeighbor(row, col) {
+				t.Errorf("the star at %s%d touches another star", letter, row)
+			}
+		}
+	}
 
 	// Print the solution
 	solved.Print("Solution found:")
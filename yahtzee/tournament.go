@@ -0,0 +1,275 @@
+package yahtzee
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+)
+
+const (
+	// InitialElo is every bot's rating before its first game
+	InitialElo = 1500
+	// EloK is the most a rating can move after one game
+	EloK = 16
+)
+
+// Tournament plays every group of bots against each other. Each group meets
+// Rounds times, and a meeting is one game for every rotation of the seats,
+// all with the same shared dice, so no bot is luckier than another.
+type Tournament struct {
+	// Bots are the AIs taking part, each with its own name
+	Bots []PlayerSpec
+	// TableSize is how many bots play in each game, 2 if zero
+	TableSize int
+	Rounds    int
+	// Seed is the first meeting's dice seed, each later meeting uses the
+	// next one
+	Seed  int64
+	Rules Rules
+	// Tunings retune the bots' strategies
+	Tunings AITunings
+}
+
+// BotRecord is one bot's results. Wins, losses and ties are counted against
+// every other bot at the table, so a game of three has two results per bot.
+type BotRecord struct {
+	Bot        PlayerSpec
+	Games      int
+	TotalScore int
+	Wins       int
+	Losses     int
+	Ties       int
+	// PointsFor and PointsAgainst total the bot's and its opponents' scores
+	// over every result
+	PointsFor     int
+	PointsAgainst int
+	Elo           float64
+}
+
+// Results is how many wins, losses and ties the bot has
+func (r BotRecord) Results() int {
+	return r.Wins + r.Losses + r.Ties
+}
+
+// WinRate is the share of results won, counting ties as half
+func (r BotRecord) WinRate() float64 {
+	if r.Results() == 0 {
+		return 0
+	}
+	return (float64(r.Wins) + float64(r.Ties)/2) / float64(r.Results())
+}
+
+// AverageScore is the bot's mean final score
+func (r BotRecord) AverageScore() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.TotalScore) / float64(r.Games)
+}
+
+// AverageDifferential is how many points the bot beat its opponents by on
+// average
+func (r BotRecord) AverageDifferential() float64 {
+	if r.Results() == 0 {
+		return 0
+	}
+	return float64(r.PointsFor-r.PointsAgainst) / float64(r.Results())
+}
+
+// TournamentResult is the leaderboard, highest rated first
+type TournamentResult struct {
+	Games   int
+	Seed    int64
+	Records []BotRecord
+}
+
+func (t Tournament) tableSize() int {
+	if t.TableSize == 0 {
+		return 2
+	}
+	return t.TableSize
+}
+
+func (t Tournament) validate() error {
+	size := t.tableSize()
+	if size < 2 {
+		return fmt.Errorf("a table needs at least 2 bots, not %d", size)
+	}
+	if len(t.Bots) < size {
+		return fmt.Errorf("%d bots can't fill a table of %d", len(t.Bots), size)
+	}
+	if t.Rounds < 1 {
+		return fmt.Errorf("a tournament needs at least 1 round, not %d", t.Rounds)
+	}
+	names := map[string]bool{}
+	for _, bot := range t.Bots {
+		if bot.Kind != AIKind {
+			return fmt.Errorf("%s isn't an AI, tournaments only play AIs", bot.Name)
+		}
+		if names[bot.Name] {
+			return fmt.Errorf("more than one bot is named %s", bot.Name)
+		}
+		names[bot.Name] = true
+	}
+	return nil
+}
+
+// Run plays every game and ranks the bots by Elo
+func (t Tournament) Run() (TournamentResult, error) {
+	if err := t.validate(); err != nil {
+		return TournamentResult{}, err
+	}
+	bots, err := t.Tunings.Apply(t.Bots)
+	if err != nil {
+		return TournamentResult{}, err
+	}
+	t.Bots = bots
+	records := make([]BotRecord, len(t.Bots))
+	for idx, bot := range t.Bots {
+		records[idx] = BotRecord{Bot: bot, Elo: InitialElo}
+	}
+
+	result := TournamentResult{Seed: t.Seed}
+	seed := t.Seed
+	for round := 0; round < t.Rounds; round++ {
+		for _, table := range combinations(len(t.Bots), t.tableSize()) {
+			for rotation := range table {
+				seats := append(append([]int{}, table[rotation:]...), table[:rotation]...)
+				scores := t.play(seats, seed)
+				recordGame(records, seats, scores)
+				result.Games++
+			}
+			seed++
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Elo > records[j].Elo
+	})
+	result.Records = records
+	return result, nil
+}
+
+// play plays one game with the bots in seat order and returns their scores
+func (t Tournament) play(seats []int, seed int64) []int {
+	lineup := make([]PlayerSpec, len(seats))
+	for seat, bot := range seats {
+		lineup[seat] = t.Bots[bot]
+	}
+	game := NewGame(lineup, seed, t.Rules, nil)
+	game.Output = io.Discard
+	game.SharedDice = true
+	game.Play()
+
+	scores := make([]int, len(seats))
+	for seat, plyr := range game.Players {
+		scores[seat] = (*plyr).GetScorecard().Total()
+	}
+	return scores
+}
+
+// recordGame adds a game's results to the records of the bots at seats and
+// moves their ratings. Every pair at the table counts as a match, and each
+// bot's rating moves by its average change over those matches.
+func recordGame(records []BotRecord, seats []int, scores []int) {
+	change := make([]float64, len(seats))
+	for i, bot := range seats {
+		record := &records[bot]
+		record.Games++
+		record.TotalScore += scores[i]
+		for j, opponent := range seats {
+			if i == j {
+				continue
+			}
+			record.PointsFor += scores[i]
+			record.PointsAgainst += scores[j]
+			outcome := 0.5
+			switch {
+			case scores[i] > scores[j]:
+				record.Wins++
+				outcome = 1
+			case scores[i] < scores[j]:
+				record.Losses++
+				outcome = 0
+			default:
+				record.Ties++
+			}
+			expected := 1 / (1 + math.Pow(10, (records[opponent].Elo-record.Elo)/400))
+			change[i] += EloK * (outcome - expected)
+		}
+	}
+	for i, bot := range seats {
+		records[bot].Elo += change[i] / float64(len(seats)-1)
+	}
+}
+
+// combinations lists every way to choose k of n indices, in order
+func combinations(n, k int) [][]int {
+	var all [][]int
+	var pick func(start int, chosen []int)
+	pick = func(start int, chosen []int) {
+		if len(chosen) == k {
+			all = append(all, append([]int{}, chosen...))
+			return
+		}
+		for idx := start; idx < n; idx++ {
+			pick(idx+1, append(chosen, idx))
+		}
+	}
+	pick(0, nil)
+	return all
+}
+
+// WriteTable writes the leaderboard as a table
+func (r TournamentResult) WriteTable(w io.Writer) {
+	fmt.Fprintf(w, "%d games from seed %d\n", r.Games, r.Seed)
+	table := [][]boardCell{{
+		{text: "Bot", style: ansiBold}, {text: "Elo", style: ansiBold}, {text: "W", style: ansiBold},
+		{text: "L", style: ansiBold}, {text: "T", style: ansiBold}, {text: "Win %", style: ansiBold},
+		{text: "Avg score", style: ansiBold}, {text: "Avg diff", style: ansiBold},
+	}}
+	for idx, record := range r.Records {
+		table = append(table, []boardCell{
+			{text: fmt.Sprintf("%d. %s", idx+1, record.Bot.Name)},
+			{text: fmt.Sprintf("%.0f", record.Elo)},
+			{text: strconv.Itoa(record.Wins)},
+			{text: strconv.Itoa(record.Losses)},
+			{text: strconv.Itoa(record.Ties)},
+			{text: fmt.Sprintf("%.1f", record.WinRate()*100)},
+			{text: fmt.Sprintf("%.1f", record.AverageScore())},
+			{text: fmt.Sprintf("%+.1f", record.AverageDifferential())},
+		})
+	}
+	writeTable(w, table, IsTerminal(w))
+}
+
+// WriteCSV writes the leaderboard as CSV with a header row
+func (r TournamentResult) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{
+		"rank", "bot", "strategy", "elo", "games", "wins", "losses", "ties",
+		"win_rate", "average_score", "points_for", "points_against", "average_differential",
+	})
+	for idx, record := range r.Records {
+		out.Write([]string{
+			strconv.Itoa(idx + 1),
+			record.Bot.Name,
+			record.Bot.Strategy,
+			strconv.FormatFloat(record.Elo, 'f', 1, 64),
+			strconv.Itoa(record.Games),
+			strconv.Itoa(record.Wins),
+			strconv.Itoa(record.Losses),
+			strconv.Itoa(record.Ties),
+			strconv.FormatFloat(record.WinRate(), 'f', 3, 64),
+			strconv.FormatFloat(record.AverageScore(), 'f', 1, 64),
+			strconv.Itoa(record.PointsFor),
+			strconv.Itoa(record.PointsAgainst),
+			strconv.FormatFloat(record.AverageDifferential(), 'f', 1, 64),
+		})
+	}
+	out.Flush()
+	return out.Error()
+}
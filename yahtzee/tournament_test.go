@@ -0,0 +1,95 @@
+package yahtzee
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+)
+
+func TestSharedDiceTiesIdenticalBots(t *testing.T) {
+	bots, err := ParseLineup("ai:greedy:A,ai:greedy:B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := Tournament{Bots: bots, Rounds: 3, Seed: 5}.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, record := range result.Records {
+		if record.Ties != 6 || record.Elo != InitialElo {
+			t.Errorf("%s should have tied all 6 games at %v, got %+v", record.Bot.Name, InitialElo, record)
+		}
+	}
+}
+
+func TestTournamentSchedule(t *testing.T) {
+	bots, err := ParseLineup("ai:greedy,ai:random,ai:greedy-immediate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		tableSize, games, gamesEach, resultsEach int
+	}{
+		// 3 pairs, 2 rounds, 2 seatings
+		{2, 12, 8, 8},
+		// 1 table, 2 rounds, 3 seatings, 2 opponents a game
+		{3, 6, 6, 12},
+	} {
+		result, err := Tournament{Bots: bots, TableSize: tt.tableSize, Rounds: 2, Seed: 1}.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Games != tt.games {
+			t.Errorf("tables of %d: expected %d games, got %d", tt.tableSize, tt.games, result.Games)
+		}
+		elo := 0.0
+		for _, record := range result.Records {
+			if record.Games != tt.gamesEach || record.Results() != tt.resultsEach {
+				t.Errorf("tables of %d: %s played %d games with %d results", tt.tableSize, record.Bot.Name, record.Games, record.Results())
+			}
+			elo += record.Elo
+		}
+		if math.Abs(elo-3*InitialElo) > 1e-6 {
+			t.Errorf("tables of %d: ratings should add up to %d, got %v", tt.tableSize, 3*InitialElo, elo)
+		}
+		if last := result.Records[len(result.Records)-1]; last.Bot.Strategy != "random" {
+			t.Errorf("tables of %d: expected random last, got %s", tt.tableSize, last.Bot.Name)
+		}
+	}
+}
+
+func TestTournamentRejectsBadSetups(t *testing.T) {
+	bots, _ := ParseLineup("ai:greedy:A,ai:random:B")
+	humans, _ := ParseLineup("human:Al,ai:greedy")
+	for _, tournament := range []Tournament{
+		{Bots: bots[:1], Rounds: 1},
+		{Bots: bots, Rounds: 0},
+		{Bots: bots, TableSize: 3, Rounds: 1},
+		{Bots: humans, Rounds: 1},
+		{Bots: []PlayerSpec{bots[0], bots[0]}, Rounds: 1},
+	} {
+		if _, err := tournament.Run(); err == nil {
+			t.Errorf("expected an error for %+v", tournament)
+		}
+	}
+}
+
+func TestTournamentCSV(t *testing.T) {
+	bots, _ := ParseLineup("ai:greedy,ai:random")
+	result, err := Tournament{Bots: bots, Rounds: 1, Seed: 1}.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := result.WriteCSV(&out); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 || rows[0][0] != "rank" || rows[1][1] != "🤖 greedy" || rows[1][5] != "2" {
+		t.Errorf("unexpected CSV: %v", rows)
+	}
+}
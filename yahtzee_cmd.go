@@ -0,0 +1,237 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"kevinmchugh.me/yahtzee/m/v2/yahtzee"
+)
+
+func addRuleFlags(flags *flag.FlagSet) *yahtzee.Rules {
+	rules := &yahtzee.Rules{}
+	flags.BoolVar(&rules.NoYahtzeeBonus, "no-bonus", false, "no 100 point bonus for Yahtzees after the first")
+	flags.BoolVar(&rules.NoJokers, "no-jokers", false, "later Yahtzees don't count as full houses or straights")
+	return rules
+}
+
+// addExplainFlag adds -explain and returns where AIs should explain
+// themselves, nil if they shouldn't
+func addExplainFlag(flags *flag.FlagSet) func() io.Writer {
+	explain := flags.Bool("explain", false, "show why AIs hold the dice and take the scores they do")
+	return func() io.Writer {
+		if !*explain {
+			return nil
+		}
+		return os.Stdout
+	}
+}
+
+// addTuningFlag adds -ai-config and returns a func that loads it once the
+// flags are parsed
+func addTuningFlag(flags *flag.FlagSet) func() (yahtzee.AITunings, error) {
+	path := flags.String("ai-config", "", "JSON file retuning AI strategies, like {\"bonus-chaser\": {\"upper_point_worth\": 2}}")
+	return func() (yahtzee.AITunings, error) {
+		if *path == "" {
+			return nil, nil
+		}
+		return yahtzee.LoadAITuning(*path)
+	}
+}
+
+func addCoachFlag(flags *flag.FlagSet) *bool {
+	return flags.Bool("coach", false, "point out where people lose expected points and review their mistakes at the end")
+}
+
+func strategyList() string {
+	return strings.Join(yahtzee.AIStrategyNames(), ", ")
+}
+
+func yahtzeePlay(name string, args []string) error {
+	flags := newFlagSet(name, "", "Play Yahtzee. People take their turns at the keyboard, AIs play themselves.")
+	lineup := flags.String("players", "human:You,ai:greedy", "comma separated players in seat order: human:<name> or ai:<strategy>[:<name>], strategies are "+strategyList())
+	seed := flags.Int64("seed", 0, "seed for the dice; random if 0")
+	savePath := flags.String("save", yahtzee.DefaultSavePath(), "file the game is saved to after every turn")
+	rules := addRuleFlags(flags)
+	aiLog := addExplainFlag(flags)
+	coach := addCoachFlag(flags)
+	loadTuning := addTuningFlag(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := noArguments(flags); err != nil {
+		return err
+	}
+	tunings, err := loadTuning()
+	if err != nil {
+		return err
+	}
+	specs, err := yahtzee.ParseLineup(*lineup)
+	if err != nil {
+		return usageError{err.Error()}
+	}
+
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	fmt.Println("Seed:", *seed)
+
+	if previous, err := yahtzee.LoadGame(*savePath, nil); err == nil && !previous.Over() {
+		fmt.Println("A saved game exists. Run 'yahtzee resume' to carry on with it; starting a new game replaces it.")
+	}
+	tuned, err := tunings.Apply(specs)
+	if err != nil {
+		return usageError{err.Error()}
+	}
+	game := yahtzee.NewGame(tuned, *seed, *rules, aiLog())
+	game.SavePath = *savePath
+	if *coach {
+		game.CoachHumans(os.Stdout)
+	}
+	game.Play()
+	return nil
+}
+
+func yahtzeeResume(name string, args []string) error {
+	flags := newFlagSet(name, "", "Carry on with the saved game from the turn it stopped at. AIs keep the tuning they started with.")
+	savePath := flags.String("save", yahtzee.DefaultSavePath(), "file the game was saved to")
+	aiLog := addExplainFlag(flags)
+	coach := addCoachFlag(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := noArguments(flags); err != nil {
+		return err
+	}
+
+	game, err := yahtzee.LoadGame(*savePath, aiLog())
+	if err != nil {
+		return fmt.Errorf("could not resume the saved game: %w", err)
+	}
+	if game.Over() {
+		fmt.Println("The saved game is already over.")
+		game.WriteText(os.Stdout)
+		return nil
+	}
+	fmt.Printf("Resuming at turn %d of %d\n", game.Turn+1, game.Turns())
+	if *coach {
+		game.CoachHumans(os.Stdout)
+	}
+	game.Play()
+	return nil
+}
+
+func yahtzeeExport(name string, args []string) error {
+	flags := newFlagSet(name, "", "Write the saved game's scorecards as plain text for sharing.")
+	savePath := flags.String("save", yahtzee.DefaultSavePath(), "file the game was saved to")
+	outPath := flags.String("o", "", "file to write to; standard output if empty")
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := noArguments(flags); err != nil {
+		return err
+	}
+
+	game, err := yahtzee.LoadGame(*savePath, nil)
+	if err != nil {
+		return fmt.Errorf("could not read the saved game: %w", err)
+	}
+	if *outPath == "" {
+		game.WriteText(os.Stdout)
+		return nil
+	}
+	out, err := os.Create(*outPath)
+	if err != nil {
+		return err
+	}
+	game.WriteText(out)
+	return out.Close()
+}
+
+func yahtzeeSim(name string, args []string) error {
+	flags := newFlagSet(name, "", "Play many AI-only games and report the scores.")
+	games := flags.Int("games", 100, "number of games to play")
+	lineup := flags.String("players", "ai:greedy", "comma separated AI players in each game: ai:<strategy>[:<name>], strategies are "+strategyList())
+	seed := flags.Int64("seed", 1, "seed for the first game, later games count up from it")
+	rules := addRuleFlags(flags)
+	loadTuning := addTuningFlag(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := noArguments(flags); err != nil {
+		return err
+	}
+	tunings, err := loadTuning()
+	if err != nil {
+		return err
+	}
+	if *games < 1 {
+		return usageError{fmt.Sprintf("-games must be at least 1, got %d", *games)}
+	}
+	specs, err := yahtzee.ParseLineup(*lineup)
+	if err != nil {
+		return usageError{err.Error()}
+	}
+	for _, spec := range specs {
+		if spec.Kind != yahtzee.AIKind {
+			return usageError{fmt.Sprintf("%s isn't an AI, simulations only play AIs", spec.Name)}
+		}
+	}
+
+	simulation := yahtzee.Simulation{Players: specs, Games: *games, Seed: *seed, Rules: *rules, Tunings: tunings}
+	result, err := simulation.Run()
+	if err != nil {
+		return usageError{err.Error()}
+	}
+	result.Report(os.Stdout)
+	return nil
+}
+
+func yahtzeeTournament(name string, args []string) error {
+	flags := newFlagSet(name, "", "Play AIs against each other with shared dice and rank them by Elo.")
+	lineup := flags.String("bots", "ai:"+strings.Join(yahtzee.AIStrategyNames(), ",ai:"), "comma separated AIs taking part: ai:<strategy>[:<name>], strategies are "+strategyList())
+	tableSize := flags.Int("table", 2, "number of bots in each game")
+	rounds := flags.Int("rounds", 5, "times each table of bots meets, playing once per seat rotation")
+	seed := flags.Int64("seed", 1, "dice seed for the first meeting, later meetings count up from it")
+	csvPath := flags.String("csv", "", "file to also write the leaderboard to as CSV")
+	rules := addRuleFlags(flags)
+	loadTuning := addTuningFlag(flags)
+	if err := parseFlags(flags, args); err != nil {
+		return err
+	}
+	if err := noArguments(flags); err != nil {
+		return err
+	}
+	tunings, err := loadTuning()
+	if err != nil {
+		return err
+	}
+	specs, err := yahtzee.ParseLineup(*lineup)
+	if err != nil {
+		return usageError{err.Error()}
+	}
+
+	tournament := yahtzee.Tournament{
+		Bots: specs, TableSize: *tableSize, Rounds: *rounds, Seed: *seed, Rules: *rules, Tunings: tunings,
+	}
+	result, err := tournament.Run()
+	if err != nil {
+		return usageError{err.Error()}
+	}
+	result.WriteTable(os.Stdout)
+	if *csvPath == "" {
+		return nil
+	}
+	out, err := os.Create(*csvPath)
+	if err != nil {
+		return err
+	}
+	if err := result.WriteCSV(out); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}